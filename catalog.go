@@ -0,0 +1,202 @@
+package valy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"path"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Catalog contains the messages of the errors by locale and rule name. A message can contain the
+// placeholders {field}, {param} and {value} and it can be keyed by the kind of the field e.g. "string.min",
+// the same way as the messages of the Messages option.
+// A Catalog is safe for concurrent use.
+//
+// The messages of a locale fall back to the messages of its parent locale and then to the messages of
+// the fallback locale of the Catalog, rule by rule. For example the message of the min rule for the locale
+// "el-GR" is looked up in the locales "el-GR", "el" and the fallback locale. If none of them contains it,
+// the default message is used.
+//
+// HOW TO USE IT
+// Load the catalogs once, e.g. from an embed.FS:
+// //go:embed locales
+// var locales embed.FS
+//
+// catalog := valy.NewCatalog("en")
+// if err := catalog.LoadFS(locales, "locales/*.json"); err != nil {
+// 	panic(err)
+// }
+// validator := valy.New(valy.MessageCatalog(catalog), valy.Locale("en"))
+// errs, err := validator.ValidateCtx(valy.ContextWithLocale(ctx, "el-GR"), u)
+type Catalog struct {
+	mu sync.RWMutex
+
+	// fallback is the locale of the messages which are used by all the locales.
+	fallback string
+
+	// messages contains the messages of each locale by rule name.
+	messages map[string]map[string]string
+
+	// resolved contains the messages of each loaded locale merged with the messages of the locales it falls
+	// back to. The messages of the locales which are not loaded are cached under the empty locale.
+	resolved map[string]map[string]string
+}
+
+// NewCatalog initializes and returns an empty Catalog. The messages of the fallback locale, e.g. "en",
+// are used by all the locales which do not contain a message. The fallback can be empty.
+func NewCatalog(fallback string) *Catalog {
+	return &Catalog{
+		fallback: normalizeLocale(fallback),
+		messages: make(map[string]map[string]string),
+		resolved: make(map[string]map[string]string),
+	}
+}
+
+// Add adds the messages of the locale by rule name e.g. {"min": "{field} should be at least {param}"}.
+// The messages replace the existing messages of the same rules.
+func (c *Catalog) Add(locale string, messages map[string]string) {
+	locale = normalizeLocale(locale)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.messages[locale] == nil {
+		c.messages[locale] = make(map[string]string, len(messages))
+	}
+	for rule, message := range messages {
+		c.messages[locale][rule] = message
+	}
+	c.resolved = make(map[string]map[string]string)
+}
+
+// LoadJSON adds the messages of the locale from a JSON object of messages by rule name e.g.
+// {"required": "το πεδίο {field} είναι υποχρεωτικό"}
+func (c *Catalog) LoadJSON(locale string, data []byte) error {
+	var messages map[string]string
+	if err := json.Unmarshal(data, &messages); err != nil {
+		return errors.New("Cannot load the messages of the locale " + locale + ": " + err.Error())
+	}
+	c.Add(locale, messages)
+	return nil
+}
+
+// LoadYAML adds the messages of the locale from a YAML mapping of messages by rule name e.g.
+// required: "das Feld {field} ist erforderlich"
+func (c *Catalog) LoadYAML(locale string, data []byte) error {
+	var messages map[string]string
+	if err := yaml.Unmarshal(data, &messages); err != nil {
+		return errors.New("Cannot load the messages of the locale " + locale + ": " + err.Error())
+	}
+	c.Add(locale, messages)
+	return nil
+}
+
+// LoadFS adds the messages of the files of fsys which match the patterns, e.g. "locales/*.json", the
+// same way as fs.Glob. The locale of each file is its name without the extension e.g. "locales/el-GR.json"
+// contains the messages of the locale "el-GR". The files with the extension .json are loaded as JSON and
+// the files with the extensions .yaml and .yml as YAML. It can load an embed.FS or the files of a
+// directory by os.DirFS.
+func (c *Catalog) LoadFS(fsys fs.FS, patterns ...string) error {
+	for _, pattern := range patterns {
+		files, err := fs.Glob(fsys, pattern)
+		if err != nil {
+			return err
+		}
+		for _, file := range files {
+			data, err := fs.ReadFile(fsys, file)
+			if err != nil {
+				return err
+			}
+			ext := path.Ext(file)
+			locale := strings.TrimSuffix(path.Base(file), ext)
+			switch strings.ToLower(ext) {
+			case ".json":
+				err = c.LoadJSON(locale, data)
+			case ".yaml", ".yml":
+				err = c.LoadYAML(locale, data)
+			default:
+				err = errors.New("Cannot support the messages file " + file + ", it should be JSON or YAML")
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Messages returns the messages of the locale by rule name, including the messages of the locales it
+// falls back to. The returned map should not be modified.
+//
+// The locale usually comes from the request e.g. the Accept-Language header, so the messages are cached only
+// for the loaded locales. A locale which is not loaded shares the messages of its closest loaded parent locale,
+// or of the fallback locale.
+func (c *Catalog) Messages(locale string) map[string]string {
+	c.mu.RLock()
+	locale = c.loadedLocale(normalizeLocale(locale))
+	messages, ok := c.resolved[locale]
+	c.mu.RUnlock()
+	if ok {
+		return messages
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	messages = make(map[string]string)
+	chain := append(parentLocales(locale), c.fallback)
+	// Apply the messages from the most generic locale to the most specific one.
+	for i := len(chain) - 1; i >= 0; i-- {
+		for rule, message := range c.messages[chain[i]] {
+			messages[rule] = message
+		}
+	}
+	c.resolved[locale] = messages
+	return messages
+}
+
+// loadedLocale returns the closest loaded locale of the locale, so the locale itself or its closest parent
+// locale which contains messages, or an empty string if none of them is loaded.
+func (c *Catalog) loadedLocale(locale string) string {
+	for _, l := range parentLocales(locale) {
+		if _, ok := c.messages[l]; ok {
+			return l
+		}
+	}
+	return ""
+}
+
+// normalizeLocale returns the locale in lower case with hyphens e.g. "el_GR" is "el-gr".
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+}
+
+// parentLocales returns the locale and its parent locales e.g. "sr-latn-rs", "sr-latn" and "sr".
+func parentLocales(locale string) []string {
+	var locales []string
+	for locale != "" {
+		locales = append(locales, locale)
+		i := strings.LastIndex(locale, "-")
+		if i < 0 {
+			break
+		}
+		locale = locale[:i]
+	}
+	return locales
+}
+
+// localeKey is the key of the locale in a context.
+type localeKey struct{}
+
+// ContextWithLocale returns a copy of ctx which contains the locale of the messages e.g. "el-GR". The
+// locale of the context overrides the Locale option of the Validator.
+func ContextWithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// LocaleFromContext returns the locale of the context, or an empty string if it does not contain one.
+func LocaleFromContext(ctx context.Context) string {
+	locale, _ := ctx.Value(localeKey{}).(string)
+	return locale
+}
@@ -0,0 +1,133 @@
+package valy_test
+
+import (
+	"context"
+	"embed"
+	"github.com/cpapidas/valy"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+//go:embed testdata/locales
+var demoLocales embed.FS
+
+type demoLocalized struct {
+	Username string `validate:"required,min=5"`
+	Age      int    `validate:"min=18"`
+	Nickname string `validate:"max=3"`
+	Password string `validate:"required,err.required='{field}: *'"`
+}
+
+func demoCatalog(t *testing.T) *valy.Catalog {
+	catalog := valy.NewCatalog("en")
+	if err := catalog.LoadFS(demoLocales, "testdata/locales/*.json", "testdata/locales/*.y*ml"); err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	return catalog
+}
+
+func TestCatalog_shouldFallBackToTheParentLocales(t *testing.T) {
+	catalog := demoCatalog(t)
+	tests := []struct {
+		locale   string
+		expected map[string][]string
+	}{
+		{"el-GR", map[string][]string{
+			"Username": {"το πεδίο Username πρέπει να έχει τουλάχιστον 5 χαρακτήρες"},
+			"Age":      {"το πεδίο Age πρέπει να είναι τουλάχιστον 18"},
+			"Nickname": {"Nickname is too long"},
+			"Password": {"Password: *"},
+		}},
+		{"el_CY", map[string][]string{
+			"Username": {"το πεδίο Username πρέπει να περιέχει τουλάχιστον 5 χαρακτήρες"},
+			"Age":      {"το πεδίο Age πρέπει να είναι τουλάχιστον 18"},
+			"Nickname": {"Nickname is too long"},
+			"Password": {"Password: *"},
+		}},
+		{"fr", map[string][]string{
+			"Username": {"the field Username should contains at least 5 characters"},
+			"Age":      {"the field Age should be grater than 18"},
+			"Nickname": {"Nickname is too long"},
+			"Password": {"Password: *"},
+		}},
+	}
+	vl := valy.New(valy.MessageCatalog(catalog))
+	for _, tt := range tests {
+		errs, err := vl.ValidateCtx(valy.ContextWithLocale(context.Background(), tt.locale),
+			demoLocalized{Username: "cp", Nickname: "cpapidas"})
+		if err != nil {
+			t.Fatalf("expected nill err but got: %v", err)
+		}
+		if !reflect.DeepEqual(errs, tt.expected) {
+			t.Errorf("%s: expected %v, but got: %v", tt.locale, tt.expected, errs)
+		}
+	}
+}
+
+func TestCatalog_shouldUseTheLocaleOption(t *testing.T) {
+	vl := valy.New(valy.MessageCatalog(demoCatalog(t)), valy.Locale("el"),
+		valy.Messages(map[string]string{"max": "{field}: max {param}"}))
+	errs, err := vl.Validate(demoLocalized{Username: "", Age: 18, Nickname: "cpapidas", Password: "1"})
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	expected := map[string][]string{
+		"Username": {"το πεδίο Username είναι υποχρεωτικό", "το πεδίο Username πρέπει να περιέχει τουλάχιστον 5 χαρακτήρες"},
+		"Nickname": {"Nickname: max 3"},
+	}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("expected %v, but got: %v", expected, errs)
+	}
+	// The locale of the context overrides the Locale option.
+	errs, err = vl.ValidateCtx(valy.ContextWithLocale(context.Background(), "en"), demoLocalized{Age: 18, Password: "1"})
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	if errs["Username"][0] != "Username is required" {
+		t.Errorf("expected the message of the en locale, but got: %v", errs)
+	}
+}
+
+func TestCatalog_shouldReturnErrorsForInvalidFiles(t *testing.T) {
+	fsys := fstest.MapFS{
+		"invalid/el.json": {Data: []byte(`{"min": 1}`)},
+		"invalid/de.yaml": {Data: []byte("min: [1, 2]")},
+		"invalid/fr.txt":  {Data: []byte("min")},
+	}
+	for _, pattern := range []string{"invalid/*.json", "invalid/*.yaml", "invalid/*.txt"} {
+		if err := valy.NewCatalog("").LoadFS(fsys, pattern); err == nil {
+			t.Errorf("%s: expected an error but got nil", pattern)
+		}
+	}
+}
+
+func TestCatalog_shouldAddMessages(t *testing.T) {
+	catalog := valy.NewCatalog("")
+	catalog.Add("de", map[string]string{"required": "{field} ist erforderlich"})
+	if m := catalog.Messages("de-AT")["required"]; m != "{field} ist erforderlich" {
+		t.Errorf("expected the message of the de locale, but got: %v", m)
+	}
+	catalog.Add("de-AT", map[string]string{"required": "{field} ist Pflicht"})
+	if m := catalog.Messages("de-AT")["required"]; m != "{field} ist Pflicht" {
+		t.Errorf("expected the message of the de-AT locale, but got: %v", m)
+	}
+	if m := catalog.Messages("fr"); len(m) != 0 {
+		t.Errorf("expected no messages, but got: %v", m)
+	}
+}
+
+func TestCatalog_shouldCacheOnlyTheLoadedLocales(t *testing.T) {
+	catalog := demoCatalog(t)
+	// The locales which are not loaded share the messages of their closest loaded locale.
+	pointer := func(m map[string]string) uintptr { return reflect.ValueOf(m).Pointer() }
+	if pointer(catalog.Messages("el-GR-x-1")) != pointer(catalog.Messages("el-GR")) {
+		t.Error("expected the messages of the el-GR locale")
+	}
+	if pointer(catalog.Messages("fr-FR")) != pointer(catalog.Messages("xx-1")) {
+		t.Error("expected the messages of the fallback locale")
+	}
+	if m := catalog.Messages("fr-FR")["required"]; m != "{field} is required" {
+		t.Errorf("expected the message of the fallback locale, but got: %v", m)
+	}
+}
@@ -8,7 +8,7 @@ import (
 type user struct {
 	Username string `validate:"required=true,min=10,max=23"`
 	Password string `validate:"required=true,err=password is required"`
-	Age      int    `validate:"required=true,min=10,max=23"`
+	Age      int    `validate:"nonzero,min=10,max=23"`
 }
 
 func main() {
@@ -0,0 +1,81 @@
+package valy
+
+import (
+	"errors"
+	"fmt"
+	"github.com/cpapidas/valy/field"
+	"reflect"
+	"sort"
+)
+
+// splitDive splits the validations of a field to the rules of the field itself, the rules of its
+// elements and the rules of its map keys.
+//
+// For example the validations of the annotation `validate:"max=3,dive,keys,min=2,endkeys,min=1"`
+// are split to:
+//
+// rules = ["max=3"]
+// elemRules = ["min=1"]
+// keyRules = ["min=2"]
+//
+// The elemRules and keyRules are nil if the dive and keys rules are not defined.
+func splitDive(validations []string) (rules, elemRules, keyRules []string, err error) {
+	for i, v := range validations {
+		if v != "dive" {
+			continue
+		}
+		elemRules = validations[i+1:]
+		if len(elemRules) > 0 && elemRules[0] == "keys" {
+			end := -1
+			for j, k := range elemRules {
+				if k == "endkeys" {
+					end = j
+					break
+				}
+			}
+			if end == -1 {
+				return nil, nil, nil, errors.New("the keys rule should be closed by an endkeys rule")
+			}
+			keyRules = elemRules[1:end]
+			elemRules = elemRules[end+1:]
+		}
+		return validations[:i], elemRules, keyRules, nil
+	}
+	return validations, nil, nil, nil
+}
+
+// hasStructs reports whether the type t is a collection which contains structs, so its elements
+// have to be parsed even if the field has not any element rules.
+func hasStructs(t reflect.Type) bool {
+	for {
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+			t = t.Elem()
+		case reflect.Struct:
+			return !field.IsValueType(t)
+		default:
+			return false
+		}
+	}
+}
+
+// sortedKeys returns the keys of the map v sorted, so the errors of the map
+// elements are always collected in the same order.
+func sortedKeys(v reflect.Value) []reflect.Value {
+	keys := v.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		switch a.Kind() {
+		case reflect.String:
+			return a.String() < b.String()
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return a.Int() < b.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return a.Uint() < b.Uint()
+		case reflect.Float32, reflect.Float64:
+			return a.Float() < b.Float()
+		}
+		return fmt.Sprint(a.Interface()) < fmt.Sprint(b.Interface())
+	})
+	return keys
+}
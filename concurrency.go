@@ -0,0 +1,76 @@
+package valy
+
+import (
+	"sync"
+)
+
+// task describes the result of a value which is parsed by parseAll.
+type task struct {
+	// p is the parser of the value, which collects its errors.
+	p *parser
+
+	// err is the error of the parser.
+	err error
+
+	// panicked defines if the parser panicked.
+	panicked bool
+
+	// recovered is the value of the panic.
+	recovered interface{}
+}
+
+// parseAll calls the parse function for the values 0 to n-1 e.g. the fields of a struct or the elements of a slice.
+//
+// If the validation is sequential, the values are parsed in order and it stops at the first error. Otherwise each
+// value is parsed by a new parser, by a goroutine if a worker is available or else by the caller's goroutine, so the
+// number of goroutines is bounded and the nested values can not deadlock waiting for a worker. After all the values
+// are parsed, their errors are collected in order, up to the first value which returned an error, so the result is
+// the same as the result of the sequential validation. If a parser panicked, the panic is raised in the caller's
+// goroutine.
+func (p *parser) parseAll(n int, parse func(p *parser, i int) error) error {
+	if p.workers == nil || n < 2 {
+		for i := 0; i < n; i++ {
+			if err := parse(p, i); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	tasks := make([]task, n)
+	run := func(i int) {
+		defer func() {
+			if r := recover(); r != nil {
+				tasks[i].panicked, tasks[i].recovered = true, r
+			}
+		}()
+		tasks[i].err = parse(tasks[i].p, i)
+	}
+	var wg sync.WaitGroup
+	for i := range tasks {
+		tasks[i].p = &parser{o: p.o, root: p.root, ctx: p.ctx, messages: p.messages, workers: p.workers}
+		select {
+		case p.workers <- struct{}{}:
+			wg.Add(1)
+			go func(i int) {
+				defer func() {
+					<-p.workers
+					wg.Done()
+				}()
+				run(i)
+			}(i)
+		default:
+			run(i)
+		}
+	}
+	wg.Wait()
+	for _, t := range tasks {
+		if t.panicked {
+			panic(t.recovered)
+		}
+		p.errs = append(p.errs, t.p.errs...)
+		if t.err != nil {
+			return t.err
+		}
+	}
+	return nil
+}
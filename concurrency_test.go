@@ -0,0 +1,162 @@
+package valy_test
+
+import (
+	"context"
+	"errors"
+	"github.com/cpapidas/valy"
+	"reflect"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type demoImportItem struct {
+	SKU   string `validate:"required,slow_sku"`
+	Price int    `validate:"min=1"`
+}
+
+type demoImport struct {
+	Name   string            `validate:"required,slow_sku"`
+	Items  []demoImportItem  `validate:"max=100"`
+	Tags   map[string]string `validate:"dive,keys,min=2,endkeys,slow_sku"`
+	Groups map[string]demoImportItem
+}
+
+// demoConcurrentValidator returns a Validator with the slow_sku rule, which records the maximum number
+// of its concurrent calls.
+func demoConcurrentValidator(t *testing.T, opts ...valy.Option) (*valy.Validator, *int64) {
+	var running, max int64
+	vl := valy.New(opts...)
+	err := vl.RegisterRule("slow_sku", func(c valy.Check) (bool, error) {
+		n := atomic.AddInt64(&running, 1)
+		defer atomic.AddInt64(&running, -1)
+		for m := atomic.LoadInt64(&max); n > m && !atomic.CompareAndSwapInt64(&max, m, n); m = atomic.LoadInt64(&max) {
+		}
+		time.Sleep(time.Millisecond)
+		return len(c.Value.(string))%3 != 0, nil
+	})
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	return vl, &max
+}
+
+func demoImportData() demoImport {
+	d := demoImport{Name: "abc", Tags: map[string]string{}, Groups: map[string]demoImportItem{}}
+	for i := 0; i < 60; i++ {
+		sku := "sku-" + strconv.Itoa(i)
+		d.Items = append(d.Items, demoImportItem{SKU: sku, Price: i % 4})
+		d.Tags[strconv.Itoa(i)] = sku
+		d.Groups[sku] = demoImportItem{SKU: sku[:i%5], Price: i}
+	}
+	return d
+}
+
+func TestConcurrency_shouldReturnTheErrorsOfTheSequentialValidation(t *testing.T) {
+	d := demoImportData()
+	sequential, _ := demoConcurrentValidator(t)
+	expected, err := sequential.Errors(d)
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	if len(expected) == 0 {
+		t.Fatal("expected the errors of the data")
+	}
+	for _, workers := range []int{2, 4, 16} {
+		concurrent, max := demoConcurrentValidator(t, valy.Concurrency(workers))
+		errs, err := concurrent.Errors(d)
+		if err != nil {
+			t.Fatalf("expected nill err but got: %v", err)
+		}
+		if !reflect.DeepEqual(errs, expected) {
+			t.Errorf("expected the errors %v, but got: %v", expected, errs)
+		}
+		if *max > int64(workers) {
+			t.Errorf("expected at most %d concurrent rules, but got: %d", workers, *max)
+		}
+	}
+}
+
+func TestConcurrency_shouldStopOnTheFirstErrorOfTheSequentialValidation(t *testing.T) {
+	d := demoImportData()
+	sequential, _ := demoConcurrentValidator(t, valy.StopOnFirstError())
+	expected, err := sequential.Errors(d)
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	concurrent, _ := demoConcurrentValidator(t, valy.StopOnFirstError(), valy.Concurrency(8))
+	errs, err := concurrent.Errors(d)
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	if len(errs) != 1 || !reflect.DeepEqual(errs, expected) {
+		t.Errorf("expected the error %v, but got: %v", expected, errs)
+	}
+}
+
+func TestConcurrency_shouldBeSafeForConcurrentValidations(t *testing.T) {
+	d := demoImportData()
+	vl, max := demoConcurrentValidator(t, valy.Concurrency(3))
+	expected, err := vl.Errors(d)
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if errs, err := vl.Errors(d); err != nil || !reflect.DeepEqual(errs, expected) {
+				t.Errorf("expected the errors %v, but got: %v, %v", expected, errs, err)
+			}
+		}()
+	}
+	wg.Wait()
+	// Each validation has its own workers.
+	if *max > 4*3 {
+		t.Errorf("expected at most %d concurrent rules, but got: %d", 4*3, *max)
+	}
+}
+
+func TestConcurrency_shouldReturnTheErrorOfTheRules(t *testing.T) {
+	vl := valy.New(valy.Concurrency(4))
+	ruleErr := errors.New("the service is not available")
+	err := vl.RegisterRule("slow_sku", func(c valy.Check) (bool, error) {
+		if c.Value == "sku-7" {
+			return false, ruleErr
+		}
+		return true, nil
+	})
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	if _, err := vl.Errors(demoImportData()); !errors.Is(err, ruleErr) {
+		t.Errorf("expected the error of the rule but got: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := vl.ErrorsCtx(ctx, demoImportData()); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the canceled error but got: %v", err)
+	}
+}
+
+func TestConcurrency_shouldRaiseThePanicOfTheRules(t *testing.T) {
+	vl := valy.New(valy.Concurrency(4))
+	err := vl.RegisterRule("slow_sku", func(c valy.Check) (bool, error) {
+		if c.Value == "sku-42" {
+			panic("invalid sku")
+		}
+		return true, nil
+	})
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	defer func() {
+		if r := recover(); r != "invalid sku" {
+			t.Errorf("expected the panic of the rule but got: %v", r)
+		}
+	}()
+	_, _ = vl.Errors(demoImportData())
+}
@@ -0,0 +1,129 @@
+package valy_test
+
+import (
+	"github.com/cpapidas/valy"
+	"reflect"
+	"testing"
+)
+
+type demoCheckout struct {
+	CustomerType string
+	Country      string
+	VATNumber    string   `validate:"required_if=CustomerType business"`
+	TaxOffice    string   `validate:"required_if='CustomerType business Country GR'"`
+	Email        *string  `validate:"required_without=Phone"`
+	Phone        *string  `validate:"required_without=Email"`
+	Company      string   `validate:"required_unless=CustomerType personal"`
+	Street       string   `validate:"required_with='City PostCode'"`
+	City         string   `validate:"required_with_all='Street PostCode'"`
+	PostCode     string   `validate:"required_without_all='Email Phone'"`
+	Coupon       string   `validate:"max=10,when='CustomerType == business',required,min=5"`
+	Notes        string   `validate:"unless=Email,required"`
+	Tags         []string `validate:"when='Country != GR',max=1"`
+}
+
+func demoEmail() *string {
+	email := "info@valy.dev"
+	return &email
+}
+
+func TestValidate_shouldApplyTheConditionalRules(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     demoCheckout
+		expected map[string][]string
+	}{
+		{
+			name: "business in Greece without contact",
+			data: demoCheckout{CustomerType: "business", Country: "GR", Tags: []string{"a", "b"}},
+			expected: map[string][]string{
+				"VATNumber": {"the field VATNumber should not be empty"},
+				"TaxOffice": {"the field TaxOffice should not be empty"},
+				"Email":     {"the field Email should not be empty"},
+				"Phone":     {"the field Phone should not be empty"},
+				"Company":   {"the field Company should not be empty"},
+				"PostCode":  {"the field PostCode should not be empty"},
+				"Coupon":    {"the field Coupon should not be empty", "the field Coupon should contains at least 5 characters"},
+				"Notes":     {"the field Notes should not be empty"},
+			},
+		},
+		{
+			name: "personal in France with email",
+			data: demoCheckout{CustomerType: "personal", Country: "FR", Email: demoEmail(), Street: "Main",
+				PostCode: "75001", Coupon: "AB", Tags: []string{"a", "b"}},
+			expected: map[string][]string{
+				"City": {"the field City should not be empty"},
+				"Tags": {"the field Tags should contains max 1 items"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		errs, err := valy.Validate(tt.data)
+		if err != nil {
+			t.Fatalf("%s: expected nill err but got: %v", tt.name, err)
+		}
+		if !reflect.DeepEqual(errs, tt.expected) {
+			t.Errorf("%s: expected %v, but got: %v", tt.name, tt.expected, errs)
+		}
+	}
+}
+
+func TestValidate_shouldCheckThePresenceOfTheNumbersInTheConditionalRules(t *testing.T) {
+	type demoLine struct {
+		SKU      string
+		Qty      int      `validate:"required_with=SKU"`
+		Discount *float64 `validate:"required_with=Qty"`
+		Note     string   `validate:"required_without=Qty"`
+	}
+	errs, err := valy.Validate(demoLine{SKU: "EU-1"})
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	// The Qty field is present even if it is 0, so the Discount field is required and the Note field is not.
+	expected := map[string][]string{
+		"Discount": {"the field Discount should not be empty"},
+	}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("expected %v, but got: %v", expected, errs)
+	}
+}
+
+func TestErrors_shouldReturnTheConditionalRule(t *testing.T) {
+	errs, err := valy.Errors(demoCheckout{CustomerType: "personal", Email: demoEmail()})
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	if len(errs) != 0 {
+		t.Errorf("expected no errors, but got: %v", errs)
+	}
+	errs, err = valy.Errors(demoCheckout{CustomerType: "business", Email: demoEmail(), Company: "valy",
+		Coupon: "VALY10"})
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	if len(errs) != 1 || errs[0].Rule != "required_if" || errs[0].Param != "CustomerType business" {
+		t.Errorf("expected the error of the required_if rule, but got: %v", errs)
+	}
+}
+
+type demoInvalidCondition struct {
+	VATNumber string `validate:"required_if=CustomerType business"`
+}
+
+type demoInvalidConditionSyntax struct {
+	CustomerType string
+	VATNumber    string `validate:"when='CustomerType = business',required"`
+}
+
+type demoInvalidConditionPairs struct {
+	CustomerType string
+	VATNumber    string `validate:"required_if=CustomerType"`
+}
+
+func TestPrepare_shouldReturnErrorForInvalidConditions(t *testing.T) {
+	for _, d := range []interface{}{demoInvalidCondition{}, demoInvalidConditionSyntax{}, demoInvalidConditionPairs{}} {
+		if err := valy.Prepare(d); err == nil {
+			t.Errorf("%T: expected an error for the invalid condition but got nil", d)
+		}
+	}
+}
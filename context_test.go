@@ -0,0 +1,99 @@
+package valy_test
+
+import (
+	"context"
+	"errors"
+	"github.com/cpapidas/valy"
+	"strings"
+	"testing"
+	"time"
+)
+
+type demoTenantKey struct{}
+
+type demoTenantUser struct {
+	Username string `validate:"required,tenant_prefix"`
+	Email    string `validate:"unique_email"`
+}
+
+func (u demoTenantUser) ValidateStruct(r *valy.Report) {
+	if r.Context().Value(demoTenantKey{}) == "blocked" {
+		r.Add("", "the tenant is blocked")
+	}
+}
+
+func demoContextValidator(t *testing.T) *valy.Validator {
+	vl := valy.New()
+	err := vl.RegisterRule("tenant_prefix", func(c valy.Check) (bool, error) {
+		tenant, _ := c.Context.Value(demoTenantKey{}).(string)
+		return strings.HasPrefix(c.Value.(string), tenant+"-"), nil
+	})
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	err = vl.RegisterRule("unique_email", func(c valy.Check) (bool, error) {
+		// Simulates a query which honors the deadline of the context.
+		select {
+		case <-time.After(10 * time.Millisecond):
+			return c.Value != "taken@valy.dev", nil
+		case <-c.Context.Done():
+			return false, c.Context.Err()
+		}
+	})
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	return vl
+}
+
+func TestValidateCtx_shouldPassTheContextToTheRules(t *testing.T) {
+	vl := demoContextValidator(t)
+	ctx := context.WithValue(context.Background(), demoTenantKey{}, "acme")
+	errs, err := vl.ValidateCtx(ctx, demoTenantUser{Username: "other-cpapidas", Email: "taken@valy.dev"})
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	if len(errs["Username"]) != 1 || len(errs["Email"]) != 1 {
+		t.Errorf("expected the errors of the Username and the Email, but got: %v", errs)
+	}
+	errs, err = vl.ValidateCtx(ctx, demoTenantUser{Username: "acme-cpapidas", Email: "info@valy.dev"})
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	if len(errs) != 0 {
+		t.Errorf("expected no errors, but got: %v", errs)
+	}
+	errs, err = vl.ValidateCtx(context.WithValue(ctx, demoTenantKey{}, "blocked"),
+		demoTenantUser{Username: "blocked-cpapidas", Email: "info@valy.dev"})
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	if len(errs[valy.StructKey]) != 1 {
+		t.Errorf("expected the error of the struct hook, but got: %v", errs)
+	}
+}
+
+func TestValidateCtx_shouldHonorTheDeadline(t *testing.T) {
+	vl := demoContextValidator(t)
+	ctx, cancel := context.WithTimeout(context.WithValue(context.Background(), demoTenantKey{}, "acme"), time.Millisecond)
+	defer cancel()
+	_, err := vl.ErrorsCtx(ctx, demoTenantUser{Username: "acme-cpapidas", Email: "info@valy.dev"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the deadline error but got: %v", err)
+	}
+}
+
+func TestValidateCtx_shouldStopIfTheContextIsCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := valy.ValidateCtx(ctx, demoUser{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the canceled error but got: %v", err)
+	}
+	if _, err := valy.JValidateCtx(ctx, demoUser{}, valy.KeyTag("json")); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the canceled error but got: %v", err)
+	}
+	errs, err := valy.ErrorsCtx(context.Background(), demoUser{})
+	if err != nil || len(errs) == 0 {
+		t.Errorf("expected the errors of the demoUser but got: %v, %v", errs, err)
+	}
+}
@@ -0,0 +1,156 @@
+package valy_test
+
+import (
+	"github.com/cpapidas/valy"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type demoPriceRange struct {
+	MinPrice float64 `validate:"min=0"`
+	MaxPrice int     `validate:"gtefield=MinPrice"`
+}
+
+type demoBooking struct {
+	Password        string
+	ConfirmPassword string `validate:"eqfield=Password"`
+	Nickname        string `validate:"nefield=Password"`
+	StartDate       time.Time
+	EndDate         time.Time `validate:"gtfield=StartDate"`
+	Guests          *int
+	Rooms           int `validate:"ltefield=Guests"`
+	Prices          demoPriceRange
+	Deposit         float64   `validate:"ltfield=Prices.MaxPrice"`
+	Discounts       []float64 `validate:"dive,ltefield=Prices.MinPrice"`
+	Extra           *demoBookingExtra
+}
+
+type demoBookingExtra struct {
+	Password string `validate:"eqfield=$.Password"`
+}
+
+func TestValidate_shouldApplyTheCrossFieldRules(t *testing.T) {
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	guests := 2
+	d := demoBooking{
+		Password:        "secret",
+		ConfirmPassword: "secrets",
+		Nickname:        "secret",
+		StartDate:       start,
+		EndDate:         start.Add(-time.Hour),
+		Guests:          &guests,
+		Rooms:           3,
+		Prices:          demoPriceRange{MinPrice: 10.5, MaxPrice: 10},
+		Deposit:         10,
+		Discounts:       []float64{10.5, 11},
+		Extra:           &demoBookingExtra{Password: "other"},
+	}
+	errs, err := valy.Errors(&d)
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	expected := []string{
+		"the field ConfirmPassword should be equal to the field Password",
+		"the field Nickname should not be equal to the field Password",
+		"the field EndDate should be greater than the field StartDate",
+		"the field Rooms should be less than or equal to the field Guests",
+		"the field Prices.MaxPrice should be greater than or equal to the field MinPrice",
+		"the field Deposit should be less than the field Prices.MaxPrice",
+		"the field Discounts[1] should be less than or equal to the field Prices.MinPrice",
+		"the field Extra.Password should be equal to the field $.Password",
+	}
+	var messages []string
+	for _, e := range errs {
+		messages = append(messages, e.Message)
+	}
+	if !reflect.DeepEqual(messages, expected) {
+		t.Errorf("expected %q, but got: %q", expected, messages)
+	}
+	if errs[0].Rule != "eqfield" || errs[0].Param != "Password" || errs[0].Value != "secrets" {
+		t.Errorf("expected the structured error of the eqfield rule, but got: %v", errs[0])
+	}
+}
+
+func TestValidate_shouldSkipTheCrossFieldRulesOfNilFields(t *testing.T) {
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	d := demoBooking{
+		Password:        "secret",
+		ConfirmPassword: "secret",
+		StartDate:       start,
+		EndDate:         start.Add(time.Hour),
+		Rooms:           3,
+		Prices:          demoPriceRange{MaxPrice: 1},
+	}
+	errs, err := valy.Validate(d)
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	if len(errs) != 0 {
+		t.Errorf("expected no errors, but got: %v", errs)
+	}
+}
+
+type demoExactCrossField struct {
+	Limit   uint64
+	Amount  int64    `validate:"gtfield=Limit"`
+	Ceiling float64  `validate:"gtfield=Amount"`
+	Balance *big.Int `validate:"ltefield=Amount"`
+	Total   demoMoney
+	Paid    float64 `validate:"eqfield=Total"`
+}
+
+func TestValidate_shouldCompareTheNumbersOfTheCrossFieldRulesExactly(t *testing.T) {
+	d := demoExactCrossField{
+		Limit:   1 << 53,
+		Amount:  1<<53 + 1,
+		Ceiling: 1 << 53,
+		Balance: big.NewInt(1<<53 + 2),
+		Total:   demoMoney{cents: 1999},
+		Paid:    19.99,
+	}
+	errs, err := valy.Validate(d)
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	expected := map[string][]string{
+		"Ceiling": {"the field Ceiling should be greater than the field Amount"},
+		"Balance": {"the field Balance should be less than or equal to the field Amount"},
+	}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("expected %v, but got: %v", expected, errs)
+	}
+}
+
+type demoInvalidCrossField struct {
+	Password        string
+	ConfirmPassword string `validate:"eqfield=Pasword"`
+}
+
+type demoNotComparable struct {
+	Tags  []string
+	Count int `validate:"gtfield=Tags"`
+}
+
+type demoUnexportedCrossField struct {
+	Updated time.Time `validate:"gtefield=created"`
+	created time.Time
+}
+
+func TestValidate_shouldReturnErrorForInvalidCrossFields(t *testing.T) {
+	err := valy.Prepare(demoInvalidCrossField{})
+	expected := "Invalid rules of the field valy_test.demoInvalidCrossField.ConfirmPassword: " +
+		"the field Pasword of the rule eqfield is not found"
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected the error %s, but got: %v", expected, err)
+	}
+	if _, err := valy.Validate(demoNotComparable{}); err == nil {
+		t.Error("expected an error for the not comparable fields but got nil")
+	}
+	_, err = valy.Validate(demoUnexportedCrossField{Updated: time.Now(), created: time.Now()})
+	if err == nil || !strings.Contains(err.Error(), "the field created is unexported") {
+		t.Errorf("expected an error for the unexported field, but got: %v", err)
+	}
+}
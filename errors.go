@@ -0,0 +1,88 @@
+package valy
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/cpapidas/valy/field"
+	"strings"
+)
+
+// FieldError describes a failed rule of a field. It contains the field's path, the rule's name
+// and argument, the value of the field and the rendered message.
+type FieldError = field.Error
+
+// ValidationErrors contains the errors of all fields. It implements the error interface and can be
+// converted to the map[string][]string returned by the Validate function.
+//
+// The errors are ordered by the declaration order of the struct fields and then by the order of
+// the rules in the annotation. The elements of the slices are ordered by index and the elements of
+// the maps by key.
+type ValidationErrors []FieldError
+
+// Error returns the messages of all errors separated by "; ".
+func (ve ValidationErrors) Error() string {
+	messages := make([]string, len(ve))
+	for i, e := range ve {
+		messages[i] = e.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Map converts the errors to a map[string][]string. The map's key is the path of the field
+// and the value is an array of strings that contains the messages of all errors of the field.
+func (ve ValidationErrors) Map() map[string][]string {
+	var errs = make(map[string][]string)
+	for _, e := range ve {
+		errs[e.FieldName] = append(errs[e.FieldName], e.Message)
+	}
+	return errs
+}
+
+// Fields returns the paths of the fields which have errors in the order of their first error.
+func (ve ValidationErrors) Fields() []string {
+	var fields []string
+	seen := make(map[string]bool)
+	for _, e := range ve {
+		if !seen[e.FieldName] {
+			seen[e.FieldName] = true
+			fields = append(fields, e.FieldName)
+		}
+	}
+	return fields
+}
+
+// MarshalJSON encodes the errors as an ordered JSON list e.g.
+// [{"field":"Username","rule":"min","param":"10","value":"cpapidas","message":"the field Username ..."}]
+func (ve ValidationErrors) MarshalJSON() ([]byte, error) {
+	if ve == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal([]FieldError(ve))
+}
+
+// jsonObject encodes the errors as a JSON object of the messages by the field's path
+// e.g. {"Username":["the field Username ..."]}. Unlike encoding a map, the fields are
+// encoded in the order of their first error.
+func (ve ValidationErrors) jsonObject() ([]byte, error) {
+	var buf bytes.Buffer
+	errs := ve.Map()
+	buf.WriteByte('{')
+	for i, f := range ve.Fields() {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(f)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(errs[f])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
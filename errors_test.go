@@ -0,0 +1,145 @@
+package valy_test
+
+import (
+	"encoding/json"
+	"github.com/cpapidas/valy"
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func TestErrors_shouldReturnStructuredErrors(t *testing.T) {
+	age := 5
+	d := demoPatch{Username: new(string), Age: &age, Address: &demoAddress{Street: "Main", PostCode: "12345"}}
+	errs, err := valy.Errors(d, map[string]string{"Age": "invalid age"})
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	expected := valy.ValidationErrors{
+		{FieldName: "Username", Rule: "required", Param: "true", Value: "",
+			Message: "the field Username should not be empty"},
+		{FieldName: "Username", Rule: "min", Param: "5", Value: "",
+			Message: "the field Username should contains at least 5 characters"},
+		{FieldName: "Age", Rule: "min", Param: "18", Value: 5, Message: "invalid age"},
+		{FieldName: "Score", Rule: "min", Param: "10", Value: nil,
+			Message: "the field Score should be grater than 10"},
+	}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("expected %v, but got: %v", expected, errs)
+	}
+}
+
+func TestValidationErrors_shouldImplementError(t *testing.T) {
+	var err error = valy.ValidationErrors{
+		{FieldName: "Username", Rule: "required", Message: "the field Username should not be empty"},
+		{FieldName: "Age", Rule: "min", Param: "10", Message: "the field Age should be grater than 10"},
+	}
+	expected := "the field Username should not be empty; the field Age should be grater than 10"
+	if err.Error() != expected {
+		t.Errorf("expected %s, but got: %s", expected, err.Error())
+	}
+}
+
+func TestValidationErrors_Map_shouldGroupTheMessagesByField(t *testing.T) {
+	errs := valy.ValidationErrors{
+		{FieldName: "Age", Rule: "required", Message: "the field Age should not be empty"},
+		{FieldName: "Username", Rule: "required", Message: "the field Username should not be empty"},
+		{FieldName: "Age", Rule: "min", Param: "10", Message: "the field Age should be grater than 10"},
+	}
+	expected := map[string][]string{
+		"Age":      {"the field Age should not be empty", "the field Age should be grater than 10"},
+		"Username": {"the field Username should not be empty"},
+	}
+	if !reflect.DeepEqual(errs.Map(), expected) {
+		t.Errorf("expected %v, but got: %v", expected, errs.Map())
+	}
+}
+
+type demoOrdered struct {
+	Zone     string `validate:"max=2,required=true"`
+	Age      int    `validate:"nonzero,min=18"`
+	Username string `validate:"min=5"`
+}
+
+func TestJValidate_shouldEncodeTheFieldsInDeclarationOrder(t *testing.T) {
+	errs, err := valy.JValidate(demoOrdered{Zone: "EUR", Username: "cp"})
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	expected := `{"Zone":["the field Zone should contains max 2 characters"],` +
+		`"Age":["the field Age should not be zero","the field Age should be grater than 18"],` +
+		`"Username":["the field Username should contains at least 5 characters"]}`
+	if string(errs) != expected {
+		t.Errorf("expected %s, but got: %s", expected, errs)
+	}
+}
+
+func TestValidationErrors_MarshalJSON_shouldEncodeAnOrderedList(t *testing.T) {
+	errs, err := valy.Errors(demoOrdered{Zone: "EU", Age: 20, Username: "cp"})
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	b, err := json.Marshal(errs)
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	expected := `[{"field":"Username","rule":"min","param":"5","value":"cp",` +
+		`"message":"the field Username should contains at least 5 characters"}]`
+	if string(b) != expected {
+		t.Errorf("expected %s, but got: %s", expected, b)
+	}
+}
+
+func TestValidationErrors_MarshalJSON_shouldEncodeTheNotFiniteValues(t *testing.T) {
+	type demoFinite struct {
+		Amount   float64 `validate:"finite"`
+		Discount float32 `validate:"finite"`
+		Rate     float64 `validate:"finite"`
+	}
+	errs, err := valy.Errors(demoFinite{Amount: math.Inf(1), Discount: float32(math.Inf(-1)), Rate: math.NaN()})
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	b, err := json.Marshal(errs)
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	expected := `[{"field":"Amount","rule":"finite","param":"true","value":"+Inf","message":"the field Amount should be a finite number"},` +
+		`{"field":"Discount","rule":"finite","param":"true","value":"-Inf","message":"the field Discount should be a finite number"},` +
+		`{"field":"Rate","rule":"finite","param":"true","value":"NaN","message":"the field Rate should be a finite number"}]`
+	if string(b) != expected {
+		t.Errorf("expected %s, but got: %s", expected, b)
+	}
+}
+
+func TestValidationErrors_MarshalJSON_shouldEncodeTheBigNumbers(t *testing.T) {
+	type demoLedger struct {
+		Balance big.Int  `validate:"max=100,err.max='{field} is {value}'"`
+		Credit  *big.Int `validate:"max=100"`
+	}
+	errs, err := valy.Errors(demoLedger{Balance: *big.NewInt(250), Credit: big.NewInt(300)})
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	b, err := json.Marshal(errs)
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	expected := `[{"field":"Balance","rule":"max","param":"100","value":250,"message":"Balance is 250"},` +
+		`{"field":"Credit","rule":"max","param":"100","value":300,"message":"the field Credit should be less than 100"}]`
+	if string(b) != expected {
+		t.Errorf("expected %s, but got: %s", expected, b)
+	}
+}
+
+func TestValidationErrors_Fields_shouldReturnTheFieldsInOrder(t *testing.T) {
+	errs, err := valy.Errors(demoOrdered{Zone: "EUR"})
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	expected := []string{"Zone", "Age", "Username"}
+	if !reflect.DeepEqual(errs.Fields(), expected) {
+		t.Errorf("expected %v, but got: %v", expected, errs.Fields())
+	}
+}
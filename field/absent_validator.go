@@ -0,0 +1,49 @@
+package field
+
+import (
+	"strconv"
+)
+
+// absent struct describes the validator of a not provided field e.g. a nil pointer. An absent
+// validator applies only the required rule, the rest of the rules are skipped.
+type absent struct {
+	// valy.Field embedded to absent validator to have access to Field's properties.
+	*Field
+
+	// require defines if the field has to be set.
+	required bool
+}
+
+// newAbsent initializes and returns an absent.
+func newAbsent(fp *Field) *absent {
+	nv := &absent{
+		required: false,
+	}
+	nv.Field = fp
+	return nv
+}
+
+// with returns a copy of the validator, with the parsed rules, for the field fp.
+func (n *absent) with(fp *Field) validator {
+	nv := *n
+	nv.Field = fp
+	return &nv
+}
+
+// validate is responsible to validate this field. After this call
+// the function will return the errors if the field is required.
+func (n *absent) validate() ([]Error, error) {
+	if n.required {
+		n.fail("required", "the field "+n.FieldName+" should not be empty")
+	}
+	return n.Errs, nil
+}
+
+// setRules sets the rules for the current field.
+func (n *absent) setRules(rules Rules) error {
+	var err error
+	if v, ok := rules.Lookup("required"); ok {
+		n.required, err = strconv.ParseBool(v)
+	}
+	return err
+}
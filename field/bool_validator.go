@@ -0,0 +1,79 @@
+package field
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// boolean struct describes the validator for bool values. A boolean validator is
+// responsible to define the rules of validation and validate a bool property.
+type boolean struct {
+	// valy.Field embedded to boolean validator to have access to Field's properties.
+	*Field
+
+	// require defines if the field has to be provided, so it is not nil. The false is a valid value.
+	required bool
+
+	// accepted defines if the field has to be true e.g. the terms of a signup form.
+	accepted bool
+
+	// nonzero defines if the field has to be true.
+	nonzero bool
+}
+
+// newBool initializes and returns a boolean.
+func newBool(fp *Field) *boolean {
+	nv := &boolean{
+		required: false,
+		accepted: false,
+		nonzero:  false,
+	}
+	nv.Field = fp
+	return nv
+}
+
+// with returns a copy of the validator, with the parsed rules, for the field fp.
+func (n *boolean) with(fp *Field) validator {
+	nv := *n
+	nv.Field = fp
+	return &nv
+}
+
+// validate is responsible to validate this field. After this call
+// the function will return the errors if the field is invalid.
+func (n *boolean) validate() ([]Error, error) {
+	v := reflect.ValueOf(n.Value).Bool()
+	// Apply the rules in the order of the annotation.
+	for _, r := range n.Rules {
+		switch {
+		case r.Name == "required" && n.required && n.Nil:
+			n.fail("required", "the field "+n.FieldName+" should not be empty")
+		case r.Name == "accepted" && n.accepted && !v:
+			n.fail("accepted", "the field "+n.FieldName+" should be accepted")
+		case r.Name == "nonzero" && n.nonzero && !v:
+			n.fail("nonzero", "the field "+n.FieldName+" should not be false")
+		}
+	}
+	return n.Errs, nil
+}
+
+// setRules sets the rules for the current field.
+func (n *boolean) setRules(rules Rules) error {
+	var err error
+	for _, r := range rules {
+		switch r.Name {
+		case "required":
+			n.required, err = strconv.ParseBool(r.Param)
+		case "accepted":
+			n.accepted, err = strconv.ParseBool(r.Param)
+		case "nonzero":
+			n.nonzero, err = strconv.ParseBool(r.Param)
+		default:
+			err = n.kindRuleError(r.Name)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+package field
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// collection struct describes the validator for slices, arrays and maps. A collection validator is
+// responsible to define the rules of validation and validate the number and the uniqueness of the items.
+// The rules of the items are applied by the dive rule of the annotation.
+type collection struct {
+	// valy.Field embedded to collection validator to have access to Field's properties.
+	*Field
+
+	// min defines the min number of items.
+	min int
+
+	// max defines the max number of items.
+	max int
+
+	// require defines if the collection should contain at least one item.
+	required bool
+
+	// unique defines if the items should be unique.
+	unique bool
+
+	// nonzero defines if the collection should contain at least one item, the same way as the required rule.
+	nonzero bool
+
+	// value is the value of the field.
+	value reflect.Value
+}
+
+// newCollection initializes and returns a collection.
+func newCollection(fp *Field) *collection {
+	nv := &collection{
+		required: false,
+		unique:   false,
+		nonzero:  false,
+	}
+	nv.Field = fp
+	return nv
+}
+
+// with returns a copy of the validator, with the parsed rules, for the field fp.
+func (n *collection) with(fp *Field) validator {
+	nv := *n
+	nv.Field = fp
+	return &nv
+}
+
+// validate is responsible to validate this field. After this call
+// the function will return the errors if the field is invalid.
+func (n *collection) validate() ([]Error, error) {
+	n.value = reflect.ValueOf(n.Value)
+	// Apply the rules in the order of the annotation.
+	for _, r := range n.Rules {
+		switch {
+		case r.Name == "min":
+			n.minRule()
+		case r.Name == "max":
+			n.maxRule()
+		case r.Name == "required" && n.required:
+			n.requiredRule()
+		case r.Name == "unique" && n.unique:
+			n.uniqueRule()
+		case r.Name == "nonzero" && n.nonzero && n.value.Len() == 0:
+			n.fail("nonzero", "the field "+n.FieldName+" should not be empty")
+		}
+	}
+	return n.Errs, nil
+}
+
+// setRules sets the rules for the current field.
+func (n *collection) setRules(rules Rules) error {
+	var err error
+	for _, r := range rules {
+		switch r.Name {
+		case "min":
+			n.min, err = parseCount(r.Name, r.Param)
+		case "max":
+			n.max, err = parseCount(r.Name, r.Param)
+		case "required":
+			n.required, err = strconv.ParseBool(r.Param)
+		case "unique":
+			n.unique, err = strconv.ParseBool(r.Param)
+		case "nonzero":
+			n.nonzero, err = strconv.ParseBool(r.Param)
+		default:
+			err = n.kindRuleError(r.Name)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// minRule checks if field contains less than X items.
+func (n *collection) minRule() {
+	if n.value.Len() < n.min {
+		n.fail("min", "the field "+n.FieldName+" should contains at least "+
+			strconv.Itoa(n.min)+" items")
+	}
+}
+
+// maxRule checks if field contains more than X items.
+func (n *collection) maxRule() {
+	if n.value.Len() > n.max {
+		n.fail("max", "the field "+n.FieldName+" should contains max "+
+			strconv.Itoa(n.max)+" items")
+	}
+}
+
+// requiredRule check if field contains any item.
+func (n *collection) requiredRule() {
+	if n.value.Len() == 0 {
+		n.fail("required", "the field "+n.FieldName+" should not be empty")
+	}
+}
+
+// uniqueRule checks if field contains the same item more than once. The pointers are compared by
+// the values they point to. The items that can be compared by their value, e.g. strings, numbers and
+// structs of them, are looked up in a set and only the rest of them are compared with reflect.DeepEqual.
+func (n *collection) uniqueRule() {
+	seen := make(map[interface{}]struct{})
+	hashable := make(map[reflect.Type]bool)
+	var others []interface{}
+	duplicate := func(item interface{}) bool {
+		t := reflect.TypeOf(item)
+		h, ok := hashable[t]
+		if !ok {
+			h = isHashable(t)
+			hashable[t] = h
+		}
+		if h {
+			if _, ok := seen[item]; ok {
+				return true
+			}
+			seen[item] = struct{}{}
+			return false
+		}
+		for _, o := range others {
+			if reflect.DeepEqual(o, item) {
+				return true
+			}
+		}
+		others = append(others, item)
+		return false
+	}
+	var items []interface{}
+	if n.value.Kind() == reflect.Map {
+		iter := n.value.MapRange()
+		for iter.Next() {
+			items = append(items, indirect(iter.Value()))
+		}
+	} else {
+		for i := 0; i < n.value.Len(); i++ {
+			items = append(items, indirect(n.value.Index(i)))
+		}
+	}
+	for _, item := range items {
+		if duplicate(item) {
+			n.fail("unique", "the field "+n.FieldName+" should contains unique items")
+			return
+		}
+	}
+}
+
+// isHashable checks if the values of type t can be used as map keys and if == compares them the same
+// way as reflect.DeepEqual does. The pointers and the interfaces are excluded, because DeepEqual
+// compares the values they point to.
+func isHashable(t reflect.Type) bool {
+	if t == nil {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.Ptr, reflect.UnsafePointer, reflect.Interface, reflect.Chan, reflect.Func,
+		reflect.Map, reflect.Slice:
+		return false
+	case reflect.Array:
+		return isHashable(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if !isHashable(t.Field(i).Type) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// indirect returns the value that v points to as an interface{}.
+func indirect(v reflect.Value) interface{} {
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	return v.Interface()
+}
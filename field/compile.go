@@ -0,0 +1,297 @@
+package field
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Compiled describes the compiled validations of a field's type. The annotation rules are parsed
+// once, so a Compiled can validate many values of the same type without parsing them again.
+// A Compiled is safe for concurrent use.
+type Compiled struct {
+	// rules contains the annotation rules in the order of the annotation.
+	rules Rules
+
+	// err contains the annotation's error.
+	err string
+
+	// ruleErrs contains the annotation's errors by rule name.
+	ruleErrs map[string]string
+
+	// checkNil defines if the rules are applied to the zero value of a nil field.
+	checkNil bool
+
+	// omitEmpty defines if the rules are skipped for an empty field, except the required rule and the
+	// conditional requirement rules.
+	omitEmpty bool
+
+	// validator is the validator of the field's kind with the parsed rules.
+	validator validator
+
+	// custom defines if the rules contain any rule which is not a builtin rule, so it may be a custom rule.
+	custom bool
+
+	// cross defines if the rules contain any cross-field rule.
+	cross bool
+
+	// conditional is the validator of the conditional requirement rules. It is nil if the rules do not
+	// contain any of them.
+	conditional validator
+
+	// groups contains the rules gated by the when and unless rules.
+	groups []group
+
+	// kindErr is the error of a not supported field's kind.
+	kindErr error
+
+	// absent is the validator of a not provided field with the parsed rules.
+	absent validator
+}
+
+// group describes the rules which are gated by a when or unless rule.
+type group struct {
+	// cond is the condition of the when or unless rule.
+	cond condition
+
+	// rules contains the compiled rules after the when or unless rule.
+	rules *Compiled
+}
+
+// Compile parses the validations of a field of the given kind, e.g. "string", and returns the Compiled
+// validations. The value is any value of the field's type, usually its zero value.
+// If the rules of the validations are not valid then it returns an error.
+//
+// The rules after a when or unless rule, until the next one, are applied only if its condition is true
+// e.g. `validate:"max=20,when='CustomerType == business',required,min=9"`. The Err rules are applied
+// to the whole field wherever they are defined.
+func Compile(kind string, value interface{}, validations []string) (*Compiled, error) {
+	validations, gated, err := splitConditions(validations)
+	if err != nil {
+		return nil, err
+	}
+	fp := &Field{Kind: kind, Value: value}
+	fp.applyRules(validations)
+	c := &Compiled{rules: fp.Rules, err: fp.Err, ruleErrs: fp.RuleErrs}
+	for _, r := range c.rules {
+		c.custom = c.custom || !builtinRules[r.Name]
+		_, isCross := crossRules[r.Name]
+		c.cross = c.cross || isCross
+		if conditionalRules[r.Name] && c.conditional == nil {
+			c.conditional = newConditional(fp)
+			if err := c.conditional.setRules(fp.Rules); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, g := range gated {
+		rules, err := Compile(kind, value, g.validations)
+		if err != nil {
+			return nil, err
+		}
+		if rules.err != "" {
+			c.err = rules.err
+		}
+		for rule, message := range rules.ruleErrs {
+			if c.ruleErrs == nil {
+				c.ruleErrs = make(map[string]string)
+			}
+			c.ruleErrs[rule] = message
+		}
+		c.groups = append(c.groups, group{cond: g.cond, rules: rules})
+	}
+	for _, g := range c.groups {
+		g.rules.err, g.rules.ruleErrs = "", c.ruleErrs
+	}
+	if c.checkNil, err = strconv.ParseBool(fp.ruleOr("checknil", "false")); err != nil {
+		return nil, err
+	}
+	if c.omitEmpty, err = strconv.ParseBool(fp.ruleOr("omitempty", "false")); err != nil {
+		return nil, err
+	}
+	if c.validator, c.kindErr = fp.kindValidator(); c.kindErr == nil {
+		if err := c.validator.setRules(fp.Rules); err != nil {
+			return nil, err
+		}
+	} else if value != nil {
+		// The kinds without a validator accept only the generic rules and the custom rules. The kind of an
+		// interface is known during the validation.
+		for _, r := range fp.Rules {
+			if isKindRule(r.Name) {
+				return nil, c.kindErr
+			}
+		}
+	}
+	c.absent = newAbsent(fp)
+	if err := c.absent.setRules(fp.Rules); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// CheckRules returns an error if any rule of the validations, or of the rules gated by a when or unless rule,
+// is neither a builtin rule nor a custom rule of the Registry r.
+func (c *Compiled) CheckRules(r *Registry) error {
+	if name := unknownRule(c.rules, r); name != "" {
+		return errors.New("the rule " + name + " is unknown, it should be a builtin rule or a registered custom rule")
+	}
+	for _, g := range c.groups {
+		if err := g.rules.CheckRules(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate is responsible to identify which validator to call and validate the field fp.
+// If the field's kind is not supported then we will return an error, unless all the rules
+// of the field are custom rules, cross-field rules or conditional requirement rules.
+//
+// By default a nil field is validated only by the required rule. If the rule checknil=true is
+// set then the Field's kind validator validates the zero value. The checknil rule is ignored
+// by the kinds without a validator like the structs.
+//
+// The required rule checks that the field is provided, so a nil field does not satisfy it. The empty strings,
+// the empty collections and the zero times are not provided either, but the zero numbers, bools and durations
+// are provided, so the nonzero rule has to be used to reject them. If the rule omitempty is set then an empty
+// field, so a nil field or a field which contains the zero value or an empty collection, is validated only by
+// the required rule and the conditional requirement rules.
+//
+// The cross-field rules, the conditional requirement rules and then the custom rules of the Registry are
+// applied after the validator of the field's kind. The conditional requirement rules are applied to the nil
+// fields too. The rules gated by the when and unless rules are applied after them, if their condition is true.
+// The errors are returned in the order of the annotation rules. If any rule is neither a builtin rule nor a custom
+// rule of the Registry then it returns an error, so a misspelled or not registered rule is not skipped silently.
+// If the CustomError, or the annotation's Err, is
+// set then it returns a single error, of the first failed rule, with the CustomError or the Err message.
+func (c *Compiled) Validate(fp *Field) ([]Error, error) {
+	validateErrs, err := c.validate(fp)
+	if err != nil {
+		return nil, err
+	}
+	for _, g := range c.groups {
+		ok, err := newConditional(fp).matches(g.cond)
+		if err != nil {
+			return nil, errors.New("Cannot apply the condition of the field " + fp.FieldName + ": " + err.Error())
+		}
+		if !ok {
+			continue
+		}
+		errs, err := g.rules.validate(fp)
+		if err != nil {
+			return nil, err
+		}
+		validateErrs = append(validateErrs, errs...)
+	}
+	if fp.CustomError != "" && len(validateErrs) > 0 {
+		e := validateErrs[0]
+		e.Message = fp.CustomError
+		return []Error{e}, nil
+	}
+	if c.err != "" && len(validateErrs) > 0 {
+		e := validateErrs[0]
+		e.Message = render(c.err, e)
+		return []Error{e}, nil
+	}
+	return validateErrs, nil
+}
+
+// validate validates the field fp by the rules which are not gated by a condition and returns
+// the errors in the order of the annotation rules.
+func (c *Compiled) validate(fp *Field) ([]Error, error) {
+	fp.Rules = c.rules
+	fp.Err = c.err
+	fp.RuleErrs = c.ruleErrs
+	fp.Errs = nil
+	if c.custom {
+		if err := fp.checkCustomRules(); err != nil {
+			return nil, err
+		}
+	}
+	var v validator
+	omitted := c.omitEmpty && fp.isEmpty()
+	if fp.Nil && (!c.checkNil || c.kindErr != nil) || omitted && !fp.isPresent() {
+		v = c.absent.with(fp)
+	} else if omitted {
+		// The empty value is provided, so it satisfies the required rule.
+	} else if c.kindErr == nil {
+		v = c.validator.with(fp)
+	} else if len(c.rules) > 0 && !fp.hasOnlyGenericRules() {
+		return nil, c.kindErr
+	}
+	if v != nil {
+		if _, err := v.validate(); err != nil {
+			return nil, err
+		}
+	}
+	_, isAbsent := v.(*absent)
+	skipped := isAbsent || omitted
+	if !skipped && c.cross {
+		if _, err := newCross(fp).validate(); err != nil {
+			return nil, err
+		}
+	}
+	if c.conditional != nil {
+		if _, err := c.conditional.with(fp).validate(); err != nil {
+			return nil, err
+		}
+	}
+	if !skipped && c.custom && fp.Registry != nil {
+		if _, err := newCustom(fp).validate(); err != nil {
+			return nil, err
+		}
+	}
+	validateErrs := fp.Errs
+	// Report the errors in the order of the annotation rules.
+	if len(validateErrs) > 1 {
+		sort.SliceStable(validateErrs, func(i, j int) bool {
+			return fp.Rules.index(validateErrs[i].Rule) < fp.Rules.index(validateErrs[j].Rule)
+		})
+	}
+	return validateErrs, nil
+}
+
+// gatedRules describes the validations after a when or unless rule.
+type gatedRules struct {
+	// cond is the condition of the when or unless rule.
+	cond condition
+
+	// validations contains the validations after the when or unless rule.
+	validations []string
+}
+
+// splitConditions splits the validations to the validations which are not gated by a condition and
+// the validations after each when or unless rule.
+//
+// For example the validations of the annotation `validate:"max=20,when=Email,required,unless=Phone,min=3"`
+// are split to:
+//
+// validations = ["max=20"]
+// gated = [{cond: "Email", validations: ["required"]}, {cond: "Phone", negate: true, validations: ["min=3"]}]
+//
+// The Err rules are kept in the validations.
+func splitConditions(validations []string) ([]string, []gatedRules, error) {
+	var base []string
+	var gated []gatedRules
+	for _, v := range validations {
+		name, param, _ := strings.Cut(v, "=")
+		if name == "when" || name == "unless" {
+			cond, err := parseCondition(name, param)
+			if err != nil {
+				return nil, nil, err
+			}
+			gated = append(gated, gatedRules{cond: cond})
+			continue
+		}
+		if _, ok := errRule(name); ok || len(gated) == 0 {
+			base = append(base, v)
+			continue
+		}
+		gated[len(gated)-1].validations = append(gated[len(gated)-1].validations, v)
+	}
+	if gated == nil {
+		return validations, nil, nil
+	}
+	return base, gated, nil
+}
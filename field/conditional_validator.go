@@ -0,0 +1,224 @@
+package field
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// conditionalRules contains the conditional requirement rules. The param of a rule contains the paths of
+// the other fields, separated by spaces, and for the required_if and required_unless rules the value
+// of each field e.g. `validate:"required_if=CustomerType business"`.
+var conditionalRules = map[string]bool{
+	"required_if":          true,
+	"required_unless":      true,
+	"required_with":        true,
+	"required_with_all":    true,
+	"required_without":     true,
+	"required_without_all": true,
+}
+
+// conditional struct describes the validator of the conditional requirement rules. A conditional validator
+// is responsible to check if the field is required according to the values of the other fields.
+//
+// For example, if you have the following struct:
+//
+// struct Checkout {
+//   CustomerType string
+//   VATNumber    string `validate:"required_if=CustomerType business"`
+//   Email        *string
+//   Phone        *string `validate:"required_without=Email"`
+// }
+//
+// The VATNumber field is required if the CustomerType is "business" and the Phone field is required if
+// the Email field is not provided. The paths of the other fields are resolved the same way as the paths
+// of the cross-field rules.
+type conditional struct {
+	// valy.Field embedded to conditional validator to have access to Field's properties.
+	*Field
+
+	// params contains the parsed params of each rule.
+	params map[string][]string
+}
+
+// newConditional initializes and returns a conditional.
+func newConditional(fp *Field) *conditional {
+	nv := &conditional{}
+	nv.Field = fp
+	return nv
+}
+
+// with returns a copy of the validator, with the parsed rules, for the field fp.
+func (n *conditional) with(fp *Field) validator {
+	nv := *n
+	nv.Field = fp
+	return &nv
+}
+
+// setRules sets the rules for the current field.
+func (n *conditional) setRules(rules Rules) error {
+	for _, r := range rules {
+		if !conditionalRules[r.Name] {
+			continue
+		}
+		params := strings.Fields(r.Param)
+		if len(params) == 0 {
+			return errors.New("the rule " + r.Name + " should define the paths of the fields")
+		}
+		if (r.Name == "required_if" || r.Name == "required_unless") && len(params)%2 != 0 {
+			return errors.New("the rule " + r.Name + " should define pairs of a field path and a value")
+		}
+		if n.params == nil {
+			n.params = make(map[string][]string)
+		}
+		n.params[r.Name] = params
+	}
+	return nil
+}
+
+// validate is responsible to validate this field. After this call
+// the function will return the errors if the field is required but it is not provided.
+//
+// The rules are applied in the order of the annotation.
+func (n *conditional) validate() ([]Error, error) {
+	for _, r := range n.Rules {
+		params, ok := n.params[r.Name]
+		if !ok {
+			continue
+		}
+		required, err := n.required(r.Name, params)
+		if err != nil {
+			return nil, errors.New("Cannot apply the rule " + r.Name + " of the field " + n.FieldName + ": " + err.Error())
+		}
+		if required && !n.isPresent() {
+			n.fail(r.Name, "the field "+n.FieldName+" should not be empty")
+		}
+	}
+	return n.Errs, nil
+}
+
+// required reports whether the field is required by the rule according to the values of the other fields.
+func (n *conditional) required(rule string, params []string) (bool, error) {
+	switch rule {
+	case "required_if", "required_unless":
+		matched := true
+		for i := 0; i < len(params); i += 2 {
+			ok, err := n.matches(condition{path: params[i], op: "==", value: params[i+1]})
+			if err != nil {
+				return false, err
+			}
+			matched = matched && ok
+		}
+		return matched == (rule == "required_if"), nil
+	}
+	// The required_with rules are applied if the fields are present, the required_without rules if they are not.
+	want := rule == "required_with" || rule == "required_with_all"
+	all := strings.HasSuffix(rule, "_all")
+	for _, path := range params {
+		present, err := n.matches(condition{path: path})
+		if err != nil {
+			return false, err
+		}
+		if !all && present == want {
+			return true, nil
+		}
+		if all && present != want {
+			return false, nil
+		}
+	}
+	return all, nil
+}
+
+// matches reports whether the condition is true for the other fields.
+func (n *conditional) matches(c condition) (bool, error) {
+	v, found, err := newCross(n.Field).lookup(c.path)
+	if err != nil {
+		return false, err
+	}
+	var matched bool
+	switch c.op {
+	case "":
+		matched = found && isPresentValue(v)
+	case "==":
+		matched = found && fmt.Sprint(v.Interface()) == c.value
+	case "!=":
+		matched = !found || fmt.Sprint(v.Interface()) != c.value
+	}
+	return matched != c.negate, nil
+}
+
+// isEmpty reports whether the field is not provided or it contains the zero value of its type, or an
+// empty collection.
+func (n *Field) isEmpty() bool {
+	if n.Nil || n.Value == nil {
+		return true
+	}
+	v := reflect.ValueOf(n.Value)
+	return v.IsZero() || (isCollectionKind(v.Kind()) && v.Len() == 0)
+}
+
+// isCollectionKind reports whether the kind k is a slice, an array or a map.
+func isCollectionKind(k reflect.Kind) bool {
+	return k == reflect.Slice || k == reflect.Array || k == reflect.Map
+}
+
+// condition describes the condition of the when and unless rules, which gate the rules after them.
+//
+// For example the rules `validate:"when='CustomerType == business',required,min=9"` are applied only if the
+// CustomerType field is "business". The condition can be:
+//
+// Path           the field is present, so it is not nil and it does not contain the zero value
+// Path == value  the field is equal to the value
+// Path != value  the field is not equal to the value
+//
+// The unless rule negates the condition e.g. `validate:"unless=Email,required"`.
+type condition struct {
+	// path is the path of the other field.
+	path string
+
+	// op is the operator of the condition "==", "!=" or empty if it checks the presence of the field.
+	op string
+
+	// value is the value the other field is compared with.
+	value string
+
+	// negate defines if the condition is negated by the unless rule.
+	negate bool
+}
+
+// parseCondition parses the condition of the when or unless rule.
+func parseCondition(rule, param string) (condition, error) {
+	c := condition{negate: rule == "unless"}
+	fields := strings.Fields(param)
+	switch {
+	case len(fields) == 1:
+		c.path = fields[0]
+	case len(fields) == 3 && (fields[1] == "==" || fields[1] == "!="):
+		c.path, c.op, c.value = fields[0], fields[1], fields[2]
+	default:
+		return c, errors.New("Invalid condition `" + param + "` of the rule " + rule +
+			", it should be `Path`, `Path == value` or `Path != value`")
+	}
+	return c, nil
+}
+
+// conditionPaths returns the paths of the other fields of the conditional rule or the when and unless rule.
+func conditionPaths(rule, param string) []string {
+	fields := strings.Fields(param)
+	switch {
+	case rule == "when" || rule == "unless":
+		if len(fields) > 0 {
+			return fields[:1]
+		}
+	case rule == "required_if" || rule == "required_unless":
+		var paths []string
+		for i := 0; i < len(fields); i += 2 {
+			paths = append(paths, fields[i])
+		}
+		return paths
+	case conditionalRules[rule]:
+		return fields
+	}
+	return nil
+}
@@ -0,0 +1,268 @@
+package field
+
+import (
+	"errors"
+	"math/big"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// crossRules contains the cross-field rules and their default messages after the field name. The param
+// of a cross-field rule is the path of the other field e.g. `validate:"eqfield=Password"`.
+var crossRules = map[string]string{
+	"eqfield":  "should be equal to the field ",
+	"nefield":  "should not be equal to the field ",
+	"gtfield":  "should be greater than the field ",
+	"gtefield": "should be greater than or equal to the field ",
+	"ltfield":  "should be less than the field ",
+	"ltefield": "should be less than or equal to the field ",
+}
+
+// timeType is the type of time.Time, which is compared by its Before and After functions.
+var timeType = reflect.TypeOf(time.Time{})
+
+// durationType is the type of time.Duration.
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// cross struct describes the validator of the cross-field rules. A cross validator is responsible
+// to compare the field with the other fields of the struct.
+//
+// For example, if you have the following struct:
+//
+// struct Signup {
+//   Password        string `validate:"required=true"`
+//   ConfirmPassword string `validate:"eqfield=Password"`
+// }
+//
+// The ConfirmPassword field is compared with the Password field of its Parent. The path of the other field
+// is relative to the Parent, so it can be a nested field e.g. "Range.Min", or relative to the Root if it
+// starts with "$." e.g. "$.Account.Currency".
+type cross struct {
+	// valy.Field embedded to cross validator to have access to Field's properties.
+	*Field
+}
+
+// newCross initializes and returns a cross.
+func newCross(fp *Field) *cross {
+	nv := &cross{}
+	nv.Field = fp
+	return nv
+}
+
+// validate is responsible to validate this field. After this call
+// the function will return the errors if the field is invalid.
+//
+// The rules are applied in the order of the annotation. A rule is skipped if the other field
+// is a nil pointer, because it is not provided.
+func (n *cross) validate() ([]Error, error) {
+	for _, r := range n.Rules {
+		message, ok := crossRules[r.Name]
+		if !ok {
+			continue
+		}
+		other, found, err := n.lookup(r.Param)
+		if err != nil {
+			return nil, errors.New("Cannot apply the rule " + r.Name + " of the field " + n.FieldName + ": " + err.Error())
+		}
+		if !found {
+			continue
+		}
+		valid, err := compareRule(r.Name, reflect.ValueOf(n.Value), other)
+		if err != nil {
+			return nil, errors.New("Cannot apply the rule " + r.Name + " of the field " + n.FieldName + ": " + err.Error())
+		}
+		if !valid {
+			n.fail(r.Name, "the field "+n.FieldName+" "+message+r.Param)
+		}
+	}
+	return n.Errs, nil
+}
+
+// lookup returns the value of the field of the path. It returns false if any field of the path is a nil pointer.
+func (n *cross) lookup(path string) (reflect.Value, bool, error) {
+	v := n.Parent
+	if strings.HasPrefix(path, "$.") {
+		v, path = n.Root, path[2:]
+	}
+	if !v.IsValid() {
+		return reflect.Value{}, false, errors.New("the field " + path + " is not found")
+	}
+	for _, name := range strings.Split(path, ".") {
+		for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return reflect.Value{}, false, nil
+			}
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Struct {
+			return reflect.Value{}, false, errors.New("the field " + path + " is not found")
+		}
+		sf, ok := v.Type().FieldByName(name)
+		if !ok {
+			return reflect.Value{}, false, errors.New("the field " + path + " is not found")
+		}
+		var err error
+		if v, err = v.FieldByIndexErr(sf.Index); err != nil {
+			// The field is promoted from a nil embedded struct pointer.
+			return reflect.Value{}, false, nil
+		}
+	}
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}, false, nil
+		}
+		v = v.Elem()
+	}
+	if !v.CanInterface() {
+		return reflect.Value{}, false, errors.New("the field " + path + " is unexported and it can not be read")
+	}
+	return v, true, nil
+}
+
+// CheckFieldPaths checks if the paths of the cross-field rules and the conditional rules of the validations
+// are fields of the struct type parent, or of the struct parent points to. The paths relative to the root
+// struct, which start with "$.", are checked during the validation.
+func CheckFieldPaths(parent reflect.Type, validations []string) error {
+	for _, v := range validations {
+		name, param, _ := strings.Cut(v, "=")
+		paths := conditionPaths(name, param)
+		if _, ok := crossRules[name]; ok {
+			paths = []string{param}
+		}
+		for _, path := range paths {
+			if !strings.HasPrefix(path, "$.") && !hasFieldPath(parent, path) {
+				return errors.New("the field " + path + " of the rule " + name + " is not found")
+			}
+		}
+	}
+	return nil
+}
+
+// hasFieldPath reports whether the path is a field of the struct type t, or of the struct t points to.
+func hasFieldPath(t reflect.Type, path string) bool {
+	for _, f := range strings.Split(path, ".") {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return false
+		}
+		sf, ok := t.FieldByName(f)
+		if !ok {
+			return false
+		}
+		t = sf.Type
+	}
+	return true
+}
+
+// compareRule reports whether the values a and b satisfy the cross-field rule.
+func compareRule(rule string, a, b reflect.Value) (bool, error) {
+	c, err := compare(a, b)
+	if err != nil {
+		if rule == "eqfield" || rule == "nefield" {
+			// The values which are not ordered are compared by their content.
+			equal := reflect.DeepEqual(a.Interface(), b.Interface())
+			return equal == (rule == "eqfield"), nil
+		}
+		return false, err
+	}
+	switch rule {
+	case "eqfield":
+		return c == 0, nil
+	case "nefield":
+		return c != 0, nil
+	case "gtfield":
+		return c > 0, nil
+	case "gtefield":
+		return c >= 0, nil
+	case "ltfield":
+		return c < 0, nil
+	}
+	return c <= 0, nil
+}
+
+// compare returns -1, 0 or +1 if a is less than, equal to or greater than b. The numbers, including the
+// math/big numbers and the Decimal values, are compared exactly by their value, even if their types are
+// different, the strings lexicographically and the time.Time values by their instant. If the values can not
+// be compared then it returns an error.
+func compare(a, b reflect.Value) (int, error) {
+	switch {
+	case isInt(a.Kind()) && isInt(b.Kind()):
+		return compareOrdered(a.Int(), b.Int()), nil
+	case isUint(a.Kind()) && isUint(b.Kind()):
+		return compareOrdered(a.Uint(), b.Uint()), nil
+	case isFloat(a.Kind()) && isFloat(b.Kind()):
+		return compareOrdered(a.Float(), b.Float()), nil
+	case isNumberOf(a) && isNumberOf(b):
+		return compareNumbers(a.Interface(), b.Interface()), nil
+	case a.Kind() == reflect.String && b.Kind() == reflect.String:
+		return strings.Compare(a.String(), b.String()), nil
+	case a.Type() == timeType && b.Type() == timeType:
+		ta, tb := a.Interface().(time.Time), b.Interface().(time.Time)
+		if ta.Before(tb) {
+			return -1, nil
+		} else if ta.After(tb) {
+			return 1, nil
+		}
+		return 0, nil
+	}
+	return 0, errors.New("Cannot compare " + a.Type().String() + " with " + b.Type().String())
+}
+
+// compareOrdered returns -1, 0 or +1 if a is less than, equal to or greater than b.
+func compareOrdered[T int64 | uint64 | float64](a, b T) int {
+	if a < b {
+		return -1
+	} else if a > b {
+		return 1
+	}
+	return 0
+}
+
+// isInt reports whether the kind k is a signed integer.
+func isInt(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Int64
+}
+
+// isUint reports whether the kind k is an unsigned integer.
+func isUint(k reflect.Kind) bool {
+	return k >= reflect.Uint && k <= reflect.Uintptr
+}
+
+// isNumber reports whether the kind k is an integer or a float.
+func isNumber(k reflect.Kind) bool {
+	return isInt(k) || isUint(k) || k == reflect.Float32 || k == reflect.Float64
+}
+
+// isFloat reports whether the kind k is a float.
+func isFloat(k reflect.Kind) bool {
+	return k == reflect.Float32 || k == reflect.Float64
+}
+
+// isNumberOf reports whether v is an integer, a float, a math/big number or a Decimal, or a pointer to a
+// math/big number.
+func isNumberOf(v reflect.Value) bool {
+	return isNumber(v.Kind()) || isNumberValue(v.Type())
+}
+
+// compareNumbers returns -1, 0 or +1 if the number a is less than, equal to or greater than the number b. The
+// numbers are compared exactly by their decimal value. The infinite values are less or greater than all the
+// finite values.
+func compareNumbers(a, b interface{}) int {
+	ra, rb := ratOf(a), ratOf(b)
+	if ra != nil && rb != nil {
+		return ra.Cmp(rb)
+	}
+	return compareOrdered(infinityOf(a, ra), infinityOf(b, rb))
+}
+
+// infinityOf returns -1 or +1 if the number value is -Inf or +Inf, or 0 if it is finite. The rat is the
+// exact value of the number, which is nil if the number is not finite.
+func infinityOf(value interface{}, rat *big.Rat) int64 {
+	if rat != nil {
+		return 0
+	}
+	return int64(signOf(value))
+}
@@ -0,0 +1,80 @@
+package field
+
+import (
+	"context"
+	"errors"
+)
+
+// custom struct describes the validator of the custom rules. A custom validator is responsible
+// to call the registered custom rules of the field.
+type custom struct {
+	// valy.Field embedded to custom validator to have access to Field's properties.
+	*Field
+}
+
+// newCustom initializes and returns a custom.
+func newCustom(fp *Field) *custom {
+	nv := &custom{}
+	nv.Field = fp
+	return nv
+}
+
+// checkCustomRules returns an error if any rule of the field is neither a builtin rule nor a custom rule of the Registry.
+func (fp *Field) checkCustomRules() error {
+	if name := unknownRule(fp.Rules, fp.Registry); name != "" {
+		return errors.New("Cannot apply the unknown rule " + name + " of the field " + fp.FieldName +
+			", it should be a builtin rule or a registered custom rule")
+	}
+	return nil
+}
+
+// unknownRule returns the name of the first rule which is neither a builtin rule nor a custom rule of the
+// Registry r, or an empty string if all the rules are known.
+func unknownRule(rules Rules, r *Registry) string {
+	for _, rule := range rules {
+		if builtinRules[rule.Name] {
+			continue
+		}
+		if r == nil {
+			return rule.Name
+		}
+		if _, ok := r.Lookup(rule.Name); !ok {
+			return rule.Name
+		}
+	}
+	return ""
+}
+
+// validate is responsible to validate this field. After this call
+// the function will return the errors if the field is invalid.
+//
+// The rules are called in the order of the annotation. The builtin rules are skipped, they are applied by the
+// validators of the field.
+func (n *custom) validate() ([]Error, error) {
+	for _, r := range n.Rules {
+		fn, ok := n.Registry.Lookup(r.Name)
+		if !ok {
+			continue
+		}
+		c := Check{
+			FieldName: n.FieldName,
+			Value:     n.Value,
+			Param:     r.Param,
+			Context:   n.Context,
+		}
+		if c.Context == nil {
+			c.Context = context.Background()
+		}
+		if n.Parent.IsValid() {
+			c.Parent = n.Parent.Interface()
+		}
+		valid, err := fn(c)
+		if err != nil {
+			return nil, err
+		}
+		if !valid {
+			n.fail(r.Name, "the field "+n.FieldName+" does not satisfy the "+r.Name+" rule")
+		}
+	}
+	return n.Errs, nil
+}
@@ -0,0 +1,106 @@
+package field
+
+import (
+	"errors"
+	"strconv"
+	"time"
+)
+
+// durationMessages contains the default messages of the bound rules of the duration validator after the field name.
+var durationMessages = map[string]string{
+	"min": "should be at least ",
+	"gte": "should be at least ",
+	"gt":  "should be longer than ",
+	"max": "should be at most ",
+	"lte": "should be at most ",
+	"lt":  "should be shorter than ",
+}
+
+// duration struct describes the validator for time.Duration values. A duration validator is
+// responsible to define the rules of validation and validate a time.Duration property.
+//
+// The params of the bound rules are durations with units, the same way as time.ParseDuration
+// e.g. `validate:"min=500ms,max=1h30m"` or `validate:"gt=-1h"`.
+type duration struct {
+	// valy.Field embedded to duration validator to have access to Field's properties.
+	*Field
+
+	// bounds defines the bounds of the boundRules by rule name e.g. bounds["min"].
+	bounds map[string]time.Duration
+
+	// require defines if the field has to be provided, so it is not nil. A zero duration is provided.
+	required bool
+
+	// nonzero defines if the field has to be a non zero duration.
+	nonzero bool
+}
+
+// newDuration initializes and returns a duration.
+func newDuration(fp *Field) *duration {
+	nv := &duration{
+		required: false,
+		nonzero:  false,
+	}
+	nv.Field = fp
+	return nv
+}
+
+// with returns a copy of the validator, with the parsed rules, for the field fp.
+func (n *duration) with(fp *Field) validator {
+	nv := *n
+	nv.Field = fp
+	return &nv
+}
+
+// validate is responsible to validate this field. After this call
+// the function will return the errors if the field is invalid.
+func (n *duration) validate() ([]Error, error) {
+	v := n.Value.(time.Duration)
+	// Apply the rules in the order of the annotation.
+	for _, r := range n.Rules {
+		b, ok := n.bounds[r.Name]
+		switch {
+		case ok && !boundRules[r.Name](compareOrdered(int64(v), int64(b))):
+			n.fail(r.Name, "the field "+n.FieldName+" "+durationMessages[r.Name]+r.Param)
+		case r.Name == "required" && n.required && n.Nil:
+			n.fail("required", "the field "+n.FieldName+" should not be empty")
+		case r.Name == "nonzero" && n.nonzero && v == 0:
+			n.fail("nonzero", "the field "+n.FieldName+" should not be zero")
+		}
+	}
+	return n.Errs, nil
+}
+
+// setRules sets the rules for the current field.
+func (n *duration) setRules(rules Rules) error {
+	var err error
+	for _, r := range rules {
+		switch {
+		case boundRules[r.Name] != nil:
+			if n.bounds == nil {
+				n.bounds = make(map[string]time.Duration)
+			}
+			n.bounds[r.Name], err = parseDuration(r.Param)
+		case r.Name == "required":
+			n.required, err = strconv.ParseBool(r.Param)
+		case r.Name == "nonzero":
+			n.nonzero, err = strconv.ParseBool(r.Param)
+		default:
+			err = n.kindRuleError(r.Name)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseDuration parses the duration of a rule e.g. "1h30m". It returns an error if the duration has no unit,
+// except the duration "0".
+func parseDuration(s string) (time.Duration, error) {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, errors.New("Invalid duration `" + s + "`, it should be a number with a unit e.g. 300ms, 1.5h or 2h45m")
+	}
+	return d, nil
+}
@@ -0,0 +1,59 @@
+package field
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// Error describes a failed rule of a field.
+//
+// For example, if you have the following struct:
+//
+// struct User {
+//   Username string `validate:"required=true,min=10,max=23"`
+// }
+// u := &User{"cpapidas"}
+//
+// The Username field fails the min rule and the Error will have the followings values:
+//
+// FieldName = "Username"
+// Rule = "min"
+// Param = "10"
+// Value = "cpapidas"
+// Message = "the field Username should contains at least 10 characters"
+type Error struct {
+	// FieldName is the path of the field e.g. "Address.PostCode"
+	FieldName string `json:"field"`
+
+	// Rule is the name of the failed rule e.g. "min"
+	Rule string `json:"rule"`
+
+	// Param is the argument of the failed rule e.g. for the annotation `validate:"min=10"` the Param = "10"
+	Param string `json:"param,omitempty"`
+
+	// Value is the actual value of the field
+	Value interface{} `json:"value"`
+
+	// Message is the rendered message of the error.
+	Message string `json:"message"`
+}
+
+// Error returns the message of the error.
+func (e Error) Error() string {
+	return e.Message
+}
+
+// MarshalJSON encodes the error as a JSON object. JSON has no NaN and infinite numbers, so a NaN or
+// infinite Value is encoded as the string "NaN", "+Inf" or "-Inf".
+func (e Error) MarshalJSON() ([]byte, error) {
+	type plain Error
+	p := plain(e)
+	if v := reflect.ValueOf(e.Value); v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64 {
+		if f := v.Float(); math.IsNaN(f) || math.IsInf(f, 0) {
+			p.Value = strconv.FormatFloat(f, 'g', -1, 64)
+		}
+	}
+	return json.Marshal(p)
+}
@@ -1,7 +1,10 @@
 package field
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"reflect"
 	"strings"
 )
 
@@ -15,8 +18,14 @@ import (
 // An example of a Validator provider could be the string validator
 // which is responsible to validate a string property.
 type validator interface {
+	// setRules parses the rules of the property.
+	setRules(rules Rules) error
+
+	// with returns a copy of the validator, with the parsed rules, for the property fp.
+	with(fp *Field) validator
+
 	// validate validates the current property and returns any errors.
-	validate() ([]string, error)
+	validate() ([]Error, error)
 }
 
 // Field struct describes the properties of validation annotation.
@@ -33,7 +42,7 @@ type validator interface {
 // Kind = "string"
 // Value = "cpapidas"
 // FieldName = "Username"
-// Rules = {"required": "true", "min": "10", "max": "23"}
+// Rules = [{"required", "true"}, {"min", "10"}, {"max", "23"}]
 type Field struct {
 	// Kind is the field's type e.g. Kind="string"
 	Kind string
@@ -46,73 +55,289 @@ type Field struct {
 	// the FieldName = "Username"
 	FieldName string
 
-	// Rules describes the validation rules.
+	// Rules describes the validation rules in the order of the annotation.
 	// e.g. for the annotation `validate:"required=true,min=10,max=23"`
-	// Rules = {"required": "true", "min": "10", "max": "23"}
-	Rules map[string]string
+	// Rules = [{"required", "true"}, {"min", "10"}, {"max", "23"}]
+	Rules Rules
 
 	// Err property contains the annotation's error.
 	// For example for the annotation: `validate:"required=true,err=password is required"`
 	// the Err property will have the value Field.Err = "password is required"
+	// If any rule of the field fails then a single error with the Err message is returned.
 	Err string
 
+	// RuleErrs contains the annotation's errors by rule name.
+	// For example for the annotation: `validate:"required,min=8,err.min='too short'"`
+	// the RuleErrs property will have the value Field.RuleErrs = {"min": "too short"}
+	RuleErrs map[string]string
+
 	// Errs contains the Field's errors after the validation.
-	Errs []string
+	Errs []Error
 
 	// CustomError property contains the custom error for this field.
-	// By setting this property all the default Errs and Err will be overridden
+	// By setting this property all the default Errs, Err and RuleErrs will be overridden
 	// This property can be set:
 	// errMess := map[string]string{
 	// 		"Username": "Username is required and should contain between 10 and 23 characters.",
 	// }
 	// errs := valy.Validate(u, errMess)
 	CustomError string
+
+	// Nil reports whether the field is a nil pointer. A nil field is considered as not provided, so
+	// only the required rule is applied to it. The rule checknil=true changes this behavior, then all
+	// the rules are applied to Value which contains the zero value of the pointed type.
+	Nil bool
+
+	// Parent is the struct which contains the field. It is passed to the custom rules and the
+	// paths of the cross-field rules are relative to it e.g. `validate:"eqfield=Password"`.
+	Parent reflect.Value
+
+	// Root is the validated struct. The paths of the cross-field rules which start with "$."
+	// are relative to it e.g. `validate:"eqfield=$.Account.Currency"`.
+	Root reflect.Value
+
+	// Context is the context of the validation, which is passed to the custom rules. If it is nil
+	// the custom rules get the context.Background().
+	Context context.Context
+
+	// Registry contains the custom rules which can be applied to the field. The custom rules
+	// are defined in the annotation the same way as the builtin rules e.g. `validate:"sku=true"`.
+	Registry *Registry
+
+	// Messages contains the messages of the errors by rule name, which override the default messages.
+	// A message can contain the placeholders {field}, {param} and {value} e.g.
+	// Messages = {"min": "{field} should contain at least {param} characters"}
+	Messages map[string]string
 }
 
-// callValidator is responsible to identify which validator to call according to Field's kind field.
-// For example if the field is a string then we want to call the Validators.str.
-// If the type of the struct property is not supported then we will return an error.
+// callValidator is responsible to identify which validator to call according to Field's kind field
+// and returns the messages of the errors. See Validate for the details.
 func (fp *Field) CallValidator(validations []string) ([]string, error) {
-	fp.applyRules(validations)
+	validateErrs, err := fp.Validate(validations)
+	if err != nil {
+		return nil, err
+	}
 	var errs []string
-	var v validator
-	var err error
-	if fp.Kind == "string" {
-		v = newString(fp)
-	} else if isNumeric(fp.Kind) {
-		v = newNumeric(fp)
-	} else {
-		return nil, errors.New("Cannot support " + fp.Kind + " field type")
+	for _, e := range validateErrs {
+		errs = append(errs, e.Message)
 	}
-	validateErrs, err := v.validate()
+	return errs, nil
+}
+
+// Validate is responsible to identify which validator to call according to Field's kind field
+// and validate the field. The validations are compiled for each call, see Compile for the details.
+func (fp *Field) Validate(validations []string) ([]Error, error) {
+	c, err := Compile(fp.Kind, fp.Value, validations)
 	if err != nil {
 		return nil, err
 	}
-	if fp.CustomError != "" {
-		return append(errs, fp.CustomError), nil
+	return c.Validate(fp)
+}
+
+// fail adds the error of the failed rule to the Field's errors. The value of a nil field is nil.
+// The message of the rule in the RuleErrs, or else in the Messages, overrides the default message. The
+// Messages can be keyed by the kind of the field and the rule e.g. "string.min", which overrides the message
+// of the rule e.g. "min".
+func (fp *Field) fail(rule string, message string) {
+	e := Error{
+		FieldName: fp.FieldName,
+		Rule:      rule,
+		Param:     fp.Rules.Get(rule),
+		Value:     fp.Value,
+		Message:   message,
+	}
+	if fp.Nil {
+		e.Value = nil
+	}
+	if m, ok := fp.RuleErrs[rule]; ok {
+		e.Message = render(m, e)
+	} else if m, ok := fp.message(rule); ok {
+		e.Message = render(m, e)
+	}
+	fp.Errs = append(fp.Errs, e)
+}
+
+// message returns the message of the rule in the Messages, first by the kind of the field and the rule,
+// e.g. "number.min", and then by the rule.
+func (fp *Field) message(rule string) (string, bool) {
+	if len(fp.Messages) == 0 {
+		return "", false
+	}
+	if m, ok := fp.Messages[fp.messageKind()+"."+rule]; ok {
+		return m, true
+	}
+	m, ok := fp.Messages[rule]
+	return m, ok
+}
+
+// messageKind returns the kind of the field in the keys of the Messages. It is one of string, number, bool,
+// time, duration, collection and struct, e.g. all the integers, floats, math/big numbers and Decimal values
+// are numbers.
+func (fp *Field) messageKind() string {
+	t := reflect.TypeOf(fp.Value)
+	switch {
+	case t == timeType || t == nil && fp.Kind == "time.Time":
+		return "time"
+	case t == durationType || t == nil && fp.Kind == "time.Duration":
+		return "duration"
+	case t == nil && isNumeric(fp.Kind):
+		return "number"
+	case t == nil:
+		return fp.Kind
+	case isNumberValue(t):
+		return "number"
+	}
+	switch k := t.Kind(); {
+	case isNumber(k):
+		return "number"
+	case isCollectionKind(k):
+		return "collection"
+	}
+	return t.Kind().String()
+}
+
+// render replaces the placeholders {field}, {param} and {value} of the message by the path of the
+// field, the argument of the rule and the value of the field of the error e.
+func render(message string, e Error) string {
+	if !strings.Contains(message, "{") {
+		return message
+	}
+	return strings.NewReplacer(
+		"{field}", e.FieldName,
+		"{param}", e.Param,
+		"{value}", fmt.Sprint(e.Value),
+	).Replace(message)
+}
+
+// kindValidator returns the validator of the Field's kind. The validator is selected by the reflect.Kind of
+// the Field's value, so the named types e.g. `type Email string` or `type Cents int64` are validated by the
+// validator of their underlying type. The time.Time and time.Duration values have their own validators and the
+// math/big numbers and the Decimal values are validated by the numeric validator. The rest structs are validated
+// by the structure validator, which applies the rules of the struct itself. If the value is nil, the
+// validator is selected by the Field's kind name. If the kind is not supported then it returns an error.
+func (fp *Field) kindValidator() (validator, error) {
+	t := reflect.TypeOf(fp.Value)
+	switch {
+	case t == timeType || t == nil && fp.Kind == "time.Time":
+		return newTime(fp), nil
+	case t == durationType || t == nil && fp.Kind == "time.Duration":
+		return newDuration(fp), nil
+	case t == nil:
+		return fp.kindValidatorByName()
+	case isNumberValue(t):
+		return newNumeric(fp), nil
+	}
+	switch k := t.Kind(); {
+	case k == reflect.String:
+		return newString(fp), nil
+	case k == reflect.Bool:
+		return newBool(fp), nil
+	case isNumber(k) && k != reflect.Uintptr:
+		return newNumeric(fp), nil
+	case isCollectionKind(k):
+		return newCollection(fp), nil
+	case k == reflect.Struct:
+		return newStructure(fp), nil
+	}
+	return nil, errors.New("Cannot support " + fp.Kind + " field type")
+}
+
+// kindValidatorByName returns the validator of the Field's kind name e.g. "string", for a Field without a value.
+func (fp *Field) kindValidatorByName() (validator, error) {
+	if fp.Kind == "string" {
+		return newString(fp), nil
+	} else if fp.Kind == "bool" {
+		return newBool(fp), nil
+	} else if isNumeric(fp.Kind) {
+		return newNumeric(fp), nil
+	}
+	return nil, errors.New("Cannot support " + fp.Kind + " field type")
+}
+
+// isPresent reports whether the field is provided for the required rule. A nil field is not provided and the
+// empty strings, the empty collections and the zero times are not provided either. The numbers, the bools and
+// the durations are always provided, even if they contain the zero value.
+func (fp *Field) isPresent() bool {
+	if fp.Nil || fp.Value == nil {
+		return false
+	}
+	return isPresentValue(reflect.ValueOf(fp.Value))
+}
+
+// isPresentValue reports whether the dereferenced value v is provided, the same way as the isPresent function.
+func isPresentValue(v reflect.Value) bool {
+	t := v.Type()
+	switch k := t.Kind(); {
+	case t == durationType || k == reflect.Bool || isNumber(k) || isNumberValue(t):
+		return true
+	}
+	return !v.IsZero() && !(isCollectionKind(v.Kind()) && v.Len() == 0)
+}
+
+// ruleOr returns the value of the rule with the given name, or def if the rule is not defined.
+func (fp *Field) ruleOr(name, def string) string {
+	if v, ok := fp.Rules.Lookup(name); ok {
+		return v
 	}
-	return append(errs, validateErrs...), nil
+	return def
+}
+
+// hasOnlyGenericRules reports whether all the rules of the field can be applied to any kind,
+// so they are registered custom rules, cross-field rules or conditional requirement rules.
+func (fp *Field) hasOnlyGenericRules() bool {
+	for _, r := range fp.Rules {
+		if _, ok := crossRules[r.Name]; ok || conditionalRules[r.Name] {
+			continue
+		}
+		if _, ok := fp.Registry.Lookup(r.Name); !ok {
+			return false
+		}
+	}
+	return len(fp.Rules) > 0
 }
 
 // applyRules is responsible to apply the annotation rules to Rule property.
-// Each rule is described as a map[string]string property.
+// Each rule is described as a Rule property in the order of the annotation.
 // For example the rule max=23 from `validate:"required=true,min=10,max=23"`
-// will have the value Rule = {"max": "23"}
+// will have the value Rule = {Name: "max", Param: "23"}
+// A flag without a Param e.g. "required" has the Param "true". The Err rule, case-insensitive,
+// sets the Err and the err.rule e.g. err.min='too short' sets the RuleErrs of the rule.
+// If a rule is defined more than once, the last Param is kept in the position of the first rule.
 func (fp *Field) applyRules(validations []string) {
-	var rules = make(map[string]string)
+	var rules Rules
 	for _, v := range validations {
-		// Split the rule in order to get the key and the Value (e.g max=32 max->key 32->Value)
-		f := strings.Split(v, "=")
-		if f[0] == "Err" {
-			fp.Err = f[1]
+		// Split the rule in order to get the key and the Value (e.g max=32 max->key 32->Value).
+		// The Value can contain "=" e.g. regex=^a=b$
+		name, param, ok := strings.Cut(v, "=")
+		if !ok {
+			param = "true"
+		}
+		if rule, ok := errRule(name); !ok {
+			rules = rules.set(name, param)
+		} else if rule == "" {
+			fp.Err = param
 		} else {
-			rules[f[0]] = f[1]
+			if fp.RuleErrs == nil {
+				fp.RuleErrs = make(map[string]string)
+			}
+			fp.RuleErrs[rule] = param
 		}
-
 	}
 	fp.Rules = rules
 }
 
+// errRule reports whether the name is the Err rule, case-insensitive, e.g. "Err" or "err", and returns
+// the rule of the message e.g. "min" for the name "err.min", or an empty string for the field's message.
+func errRule(name string) (string, bool) {
+	if strings.EqualFold(name, "err") {
+		return "", true
+	}
+	if len(name) > 4 && strings.EqualFold(name[:4], "err.") {
+		return name[4:], true
+	}
+	return "", false
+}
+
 // isNumeric it checks if a field is numeric type in order to run the defined validator.
 func isNumeric(s string) bool {
 	numericType := []string{"int", "int8", "int16", "int32", "int64", "float", "float32", "float64", "uint", "uint",
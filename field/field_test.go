@@ -2,7 +2,11 @@ package field_test
 
 import (
 	"github.com/cpapidas/valy/field"
+	"math"
+	"math/big"
+	"reflect"
 	"testing"
+	"time"
 )
 
 func TestField_CallValidator_shouldReturnErrorForInvalidStringMin(t *testing.T) {
@@ -93,6 +97,7 @@ func TestField_CallValidator_shouldReturnErrorForInvalidNumberRequired(t *testin
 		Kind: "int",
 		Value: 0,
 		FieldName: "Age",
+		Nil: true,
 	}
 	valsErrs, err := f.CallValidator([]string{"required=true"})
 	if err != nil {
@@ -111,6 +116,7 @@ func TestField_CallValidator_shouldReturnCustomError(t *testing.T) {
 		Value: 0,
 		FieldName: "Age",
 		CustomError: "This is a custom error",
+		Nil: true,
 	}
 	valsErrs, err := f.CallValidator([]string{"required=true"})
 	if err != nil {
@@ -151,4 +157,661 @@ func TestField_CallValidator_shouldReturnFailedErrorOnNumericForInvalidRules(t *
 	if valsErrs != nil {
 		t.Error("expected to return nil results")
 	}
-}
\ No newline at end of file
+}
+func TestField_CallValidator_shouldReturnOnlyRequiredErrorForNil(t *testing.T) {
+	f := field.Field{
+		Kind:      "string",
+		Value:     "",
+		FieldName: "Username",
+		Nil:       true,
+	}
+	valsErrs, err := f.CallValidator([]string{"min=10", "required=true"})
+	if err != nil {
+		t.Fatalf("expected not return an error but got: %v", err)
+	}
+	expectedErr := "the field Username should not be empty"
+	if len(valsErrs) != 1 || valsErrs[0] != expectedErr {
+		t.Errorf("should return only the error: %s, but got %v", expectedErr, valsErrs)
+	}
+}
+
+func TestField_CallValidator_shouldValidateZeroValueOfNilWithCheckNil(t *testing.T) {
+	f := field.Field{
+		Kind:      "int",
+		Value:     0,
+		FieldName: "Age",
+		Nil:       true,
+	}
+	valsErrs, err := f.CallValidator([]string{"min=10", "checknil=true"})
+	if err != nil {
+		t.Fatalf("expected not return an error but got: %v", err)
+	}
+	expectedErr := "the field Age should be grater than 10"
+	if len(valsErrs) != 1 || valsErrs[0] != expectedErr {
+		t.Errorf("should return only the error: %s, but got %v", expectedErr, valsErrs)
+	}
+}
+
+func TestField_CallValidator_shouldReturnErrorsForInvalidCollection(t *testing.T) {
+	f := field.Field{
+		Kind:      "[]int",
+		Value:     []int{1, 2, 2},
+		FieldName: "Numbers",
+	}
+	valsErrs, err := f.CallValidator([]string{"max=2", "unique=true"})
+	if err != nil {
+		t.Fatalf("expected not return an error but got: %v", err)
+	}
+	expectedErrs := []string{"the field Numbers should contains max 2 items", "the field Numbers should contains unique items"}
+	if len(valsErrs) != 2 || valsErrs[0] != expectedErrs[0] || valsErrs[1] != expectedErrs[1] {
+		t.Errorf("should return the errors: %v, but got %v", expectedErrs, valsErrs)
+	}
+}
+
+func TestField_CallValidator_shouldCheckUniqueItemsOfAnyType(t *testing.T) {
+	one, otherOne, two := 1, 1, 2
+	tests := []struct {
+		name   string
+		kind   string
+		value  interface{}
+		unique bool
+	}{
+		{"strings", "[]string", []string{"a", "b", "c"}, true},
+		{"duplicate strings", "[]string", []string{"a", "b", "a"}, false},
+		{"pointers to the same values", "[]*int", []*int{&one, &two, &otherOne}, false},
+		{"pointers to different values", "[]*int", []*int{&one, &two}, true},
+		{"slices", "[][]int", [][]int{{1, 2}, {1}, {1, 2}}, false},
+		{"different slices", "[][]int", [][]int{{1, 2}, {1}}, true},
+		{"mixed interfaces", "[]interface {}", []interface{}{1, "1", []int{1}, int64(1)}, true},
+		{"duplicate interfaces", "[]interface {}", []interface{}{1, []int{1}, "a", []int{1}}, false},
+		{"map values", "map[string]int", map[string]int{"a": 1, "b": 2, "c": 1}, false},
+	}
+	for _, tt := range tests {
+		f := field.Field{
+			Kind:      tt.kind,
+			Value:     tt.value,
+			FieldName: "Items",
+		}
+		valsErrs, err := f.CallValidator([]string{"unique=true"})
+		if err != nil {
+			t.Fatalf("%s: expected not return an error but got: %v", tt.name, err)
+		}
+		if unique := len(valsErrs) == 0; unique != tt.unique {
+			t.Errorf("%s: expected unique %v, but got the errors %v", tt.name, tt.unique, valsErrs)
+		}
+	}
+}
+
+func TestField_Validate_shouldReturnStructuredErrors(t *testing.T) {
+	f := field.Field{
+		Kind:      "string",
+		Value:     "cpapidas",
+		FieldName: "Username",
+	}
+	valsErrs, err := f.Validate([]string{"min=10"})
+	if err != nil {
+		t.Fatalf("expected not return an error but got: %v", err)
+	}
+	expectedErr := field.Error{
+		FieldName: "Username",
+		Rule:      "min",
+		Param:     "10",
+		Value:     "cpapidas",
+		Message:   "the field Username should contains at least 10 characters",
+	}
+	if len(valsErrs) != 1 || valsErrs[0] != expectedErr {
+		t.Errorf("should return the error: %v, but got %v", expectedErr, valsErrs)
+	}
+}
+
+func TestField_Validate_shouldReturnErrorsInTheOrderOfTheRules(t *testing.T) {
+	f := field.Field{
+		Kind:      "int",
+		Value:     0,
+		FieldName: "Age",
+	}
+	valsErrs, err := f.Validate([]string{"nonzero", "min=10"})
+	if err != nil {
+		t.Fatalf("expected not return an error but got: %v", err)
+	}
+	if len(valsErrs) != 2 || valsErrs[0].Rule != "nonzero" || valsErrs[1].Rule != "min" {
+		t.Errorf("should return the errors of the nonzero and min rules, but got %v", valsErrs)
+	}
+}
+
+func TestCompile_shouldValidateManyValues(t *testing.T) {
+	c, err := field.Compile("string", "", []string{"required=true", "max=3"})
+	if err != nil {
+		t.Fatalf("expected not return an error but got: %v", err)
+	}
+	valsErrs, err := c.Validate(&field.Field{Kind: "string", Value: "", FieldName: "Zone"})
+	if err != nil {
+		t.Fatalf("expected not return an error but got: %v", err)
+	}
+	if len(valsErrs) != 1 || valsErrs[0].Rule != "required" {
+		t.Errorf("should return the error of the required rule, but got %v", valsErrs)
+	}
+	valsErrs, err = c.Validate(&field.Field{Kind: "string", Value: "EURO", FieldName: "Zone"})
+	if err != nil {
+		t.Fatalf("expected not return an error but got: %v", err)
+	}
+	if len(valsErrs) != 1 || valsErrs[0].Rule != "max" {
+		t.Errorf("should return the error of the max rule, but got %v", valsErrs)
+	}
+}
+
+func TestCompile_shouldReturnErrorForInvalidRules(t *testing.T) {
+	if _, err := field.Compile("int", 0, []string{"min=rule"}); err == nil {
+		t.Error("expected to return an error but got nil")
+	}
+}
+
+func TestField_Validate_shouldValidateStringFormats(t *testing.T) {
+	tests := []struct {
+		rule    string
+		valid   []string
+		invalid []string
+	}{
+		{"email=true", []string{"user@example.com", "first.last+tag@sub.example.eu"}, []string{"", "user", "user@", "User <user@example.com>"}},
+		{"url=true", []string{"https://example.com", "ftp://example.com/a?b=c"}, []string{"", "example.com", "/path", "http://"}},
+		{"uuid=true", []string{"123e4567-e89b-12d3-a456-426614174000"}, []string{"", "123e4567e89b12d3a456426614174000", "123e4567-e89b-12d3-a456-42661417400z"}},
+		{"hostname=true", []string{"example.com", "api-1.example.com.", "localhost"}, []string{"", "-example.com", "exa mple.com", "example..com"}},
+		{"ip=true", []string{"192.168.1.1", "2001:db8::1"}, []string{"", "256.1.1.1", "example.com"}},
+		{"ipv4=true", []string{"10.0.0.1"}, []string{"2001:db8::1", "::ffff:10.0.0.1"}},
+		{"ipv6=true", []string{"2001:db8::1", "::ffff:10.0.0.1"}, []string{"10.0.0.1"}},
+		{"cidr=true", []string{"10.0.0.0/8", "2001:db8::/32"}, []string{"10.0.0.1", "10.0.0.0/33"}},
+		{"hex=true", []string{"deadBEEF", "0123"}, []string{"", "0x12", "xyz"}},
+		{"base64=true", []string{"dmFseQ==", "YWJj"}, []string{"", "dmFseQ", "not base64"}},
+		{"alpha=true", []string{"valy", "Καλημέρα"}, []string{"", "valy1", "va ly"}},
+		{"alphanumeric=true", []string{"valy1", "Αθήνα2004"}, []string{"", "valy-1", "va ly"}},
+		{"prefix=EU-", []string{"EU-1234"}, []string{"", "US-1234"}},
+		{"suffix=.go", []string{"valy.go"}, []string{"", "valy.rs"}},
+		{"contains=@", []string{"a@b"}, []string{"", "ab"}},
+		{"regex=[0-9]+", []string{"abc123", "1"}, []string{"", "abc"}},
+		{"pattern=[0-9]+", []string{"123"}, []string{"", "abc123"}},
+		{"regex=^a=b$", []string{"a=b"}, []string{"a"}},
+	}
+	for _, tt := range tests {
+		for _, v := range tt.valid {
+			f := field.Field{Kind: "string", Value: v, FieldName: "Value"}
+			valsErrs, err := f.Validate([]string{tt.rule})
+			if err != nil {
+				t.Fatalf("expected not return an error but got: %v", err)
+			}
+			if len(valsErrs) != 0 {
+				t.Errorf("%s: expected %q to be valid, but got %v", tt.rule, v, valsErrs)
+			}
+		}
+		for _, v := range tt.invalid {
+			f := field.Field{Kind: "string", Value: v, FieldName: "Value"}
+			valsErrs, err := f.Validate([]string{tt.rule})
+			if err != nil {
+				t.Fatalf("expected not return an error but got: %v", err)
+			}
+			if len(valsErrs) != 1 {
+				t.Errorf("%s: expected %q to be invalid, but got %v", tt.rule, v, valsErrs)
+			}
+		}
+	}
+}
+
+func TestField_CallValidator_shouldReturnTheMessagesOfStringFormats(t *testing.T) {
+	f := field.Field{Kind: "string", Value: "cpapidas", FieldName: "Email"}
+	valsErrs, err := f.CallValidator([]string{"email=true", "prefix=info", "pattern=[a-z]{3}"})
+	if err != nil {
+		t.Fatalf("expected not return an error but got: %v", err)
+	}
+	expected := []string{
+		"the field Email should be a valid email address",
+		"the field Email should start with info",
+		"the field Email should match the pattern [a-z]{3}",
+	}
+	if len(valsErrs) != len(expected) {
+		t.Fatalf("should return the errors: %v, but got %v", expected, valsErrs)
+	}
+	for i := range expected {
+		if valsErrs[i] != expected[i] {
+			t.Errorf("should return the error: %s, but got %s", expected[i], valsErrs[i])
+		}
+	}
+}
+
+func TestField_CallValidator_shouldNotApplyDisabledStringFormats(t *testing.T) {
+	f := field.Field{Kind: "string", Value: "cpapidas", FieldName: "Email"}
+	valsErrs, err := f.CallValidator([]string{"email=false"})
+	if err != nil {
+		t.Fatalf("expected not return an error but got: %v", err)
+	}
+	if len(valsErrs) != 0 {
+		t.Errorf("should not return errors, but got %v", valsErrs)
+	}
+}
+
+func TestCompile_shouldReturnErrorForInvalidRegex(t *testing.T) {
+	if _, err := field.Compile("string", "", []string{"regex=[a-z"}); err == nil {
+		t.Error("expected to return an error but got nil")
+	}
+	if _, err := field.Compile("string", "", []string{"email=yes"}); err == nil {
+		t.Error("expected to return an error but got nil")
+	}
+}
+
+func TestField_Validate_shouldCountTheCharactersOfTheString(t *testing.T) {
+	tests := []struct {
+		value string
+		rules []string
+		errs  int
+	}{
+		{"Καλημέρα!!", []string{"min=10", "max=10"}, 0},
+		{"Καλημέρα!!", []string{"count=bytes", "max=10"}, 1},
+		{"Καλημέρα!!", []string{"maxbytes=10"}, 1},
+		{"Καλημέρα!!", []string{"minbytes=18", "maxbytes=18"}, 0},
+		{"👍🏽👩‍💻🇬🇷e\u0301", []string{"count=graphemes", "min=4", "max=4"}, 0},
+		{"👍🏽👩‍💻🇬🇷e\u0301", []string{"max=4"}, 1},
+		{"\u1100\u1161\u11a8\r\n\u06001", []string{"count=graphemes", "min=3", "max=3"}, 0},
+		{"日本語ab", []string{"count=width", "min=8", "max=8"}, 0},
+		{"日本語ab", []string{"max=5"}, 0},
+	}
+	for _, tt := range tests {
+		f := field.Field{Kind: "string", Value: tt.value, FieldName: "Name"}
+		valsErrs, err := f.Validate(tt.rules)
+		if err != nil {
+			t.Fatalf("expected not return an error but got: %v", err)
+		}
+		if len(valsErrs) != tt.errs {
+			t.Errorf("%q %v: expected %d errors, but got %v", tt.value, tt.rules, tt.errs, valsErrs)
+		}
+	}
+}
+
+func TestField_CallValidator_shouldReturnTheUnitOfTheCount(t *testing.T) {
+	f := field.Field{Kind: "string", Value: "日本語", FieldName: "Name"}
+	valsErrs, err := f.CallValidator([]string{"count=width", "max=4", "maxbytes=8"})
+	if err != nil {
+		t.Fatalf("expected not return an error but got: %v", err)
+	}
+	expected := []string{"the field Name should contains max 4 columns", "the field Name should contains max 8 bytes"}
+	if len(valsErrs) != 2 || valsErrs[0] != expected[0] || valsErrs[1] != expected[1] {
+		t.Errorf("should return the errors: %v, but got %v", expected, valsErrs)
+	}
+}
+
+func TestField_Validate_shouldCheckTheNormalizationForm(t *testing.T) {
+	f := field.Field{Kind: "string", Value: "e\u0301", FieldName: "Name"}
+	valsErrs, err := f.Validate([]string{"normalized=NFC"})
+	if err != nil {
+		t.Fatalf("expected not return an error but got: %v", err)
+	}
+	if len(valsErrs) != 1 || valsErrs[0].Message != "the field Name should be in the Unicode normalization form NFC" {
+		t.Errorf("should return the error of the normalized rule, but got %v", valsErrs)
+	}
+	for _, rule := range []string{"normalized=NFD", "normalized=nfkd"} {
+		valsErrs, err = f.Validate([]string{rule})
+		if err != nil {
+			t.Fatalf("expected not return an error but got: %v", err)
+		}
+		if len(valsErrs) != 0 {
+			t.Errorf("%s: should not return errors, but got %v", rule, valsErrs)
+		}
+	}
+	f.Value = "ﬁ"
+	if valsErrs, _ = f.Validate([]string{"normalized=NFKC"}); len(valsErrs) != 1 {
+		t.Errorf("should return the error of the normalized rule, but got %v", valsErrs)
+	}
+}
+
+func TestCompile_shouldReturnErrorForInvalidCounts(t *testing.T) {
+	for _, rule := range []string{"count=letters", "normalized=NFX", "maxbytes=ten"} {
+		if _, err := field.Compile("string", "", []string{rule}); err == nil {
+			t.Errorf("%s: expected to return an error but got nil", rule)
+		}
+	}
+}
+
+func TestField_Validate_shouldApplyTheAnnotationErrors(t *testing.T) {
+	tests := []struct {
+		rules    []string
+		expected []string
+	}{
+		{[]string{"required", "min=8", "Err=password is required"}, []string{"password is required"}},
+		{[]string{"required", "min=8", "err=password is required"}, []string{"password is required"}},
+		{[]string{"required", "min=8", "ERR={field} is not valid"}, []string{"Password is not valid"}},
+		{[]string{"required", "min=8", "err.min={field} should contain {param} characters, not {value}"},
+			[]string{"the field Password should not be empty", "Password should contain 8 characters, not "}},
+		{[]string{"min=8", "Err.min=too short", "err=not valid"}, []string{"not valid"}},
+	}
+	for _, tt := range tests {
+		f := field.Field{Kind: "string", Value: "", FieldName: "Password"}
+		valsErrs, err := f.CallValidator(tt.rules)
+		if err != nil {
+			t.Fatalf("expected not return an error but got: %v", err)
+		}
+		if !reflect.DeepEqual(valsErrs, tt.expected) {
+			t.Errorf("%v: should return the errors: %q, but got %q", tt.rules, tt.expected, valsErrs)
+		}
+	}
+}
+
+func TestField_Validate_shouldPreferTheAnnotationErrorsToTheMessages(t *testing.T) {
+	f := field.Field{Kind: "string", Value: "", FieldName: "Password", Messages: map[string]string{
+		"required": "{field} is missing",
+		"min":      "{field} is short",
+	}}
+	valsErrs, err := f.Validate([]string{"required", "min=8", "err.min=too short"})
+	if err != nil {
+		t.Fatalf("expected not return an error but got: %v", err)
+	}
+	if len(valsErrs) != 2 || valsErrs[0].Message != "Password is missing" || valsErrs[1].Message != "too short" {
+		t.Errorf("should return the messages of the annotation and the Messages, but got %v", valsErrs)
+	}
+	if valsErrs[1].Rule != "min" || valsErrs[1].Param != "8" {
+		t.Errorf("should keep the rule of the error, but got %v", valsErrs[1])
+	}
+}
+
+func BenchmarkField_Validate(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		f := field.Field{Kind: "string", Value: "cpapidas", FieldName: "Username"}
+		if _, err := f.Validate([]string{"required=true", "min=10", "max=23"}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCompiled_Validate(b *testing.B) {
+	c, err := field.Compile("string", "", []string{"required=true", "min=10", "max=23"})
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		f := field.Field{Kind: "string", Value: "cpapidas", FieldName: "Username"}
+		if _, err := c.Validate(&f); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestField_Validate_shouldValidateBools(t *testing.T) {
+	tests := []struct {
+		value    bool
+		rules    []string
+		expected []string
+	}{
+		{false, []string{"accepted"}, []string{"the field Terms should be accepted"}},
+		{false, []string{"nonzero"}, []string{"the field Terms should not be false"}},
+		{false, []string{"required"}, nil},
+		{false, []string{"accepted=false"}, nil},
+		{true, []string{"required", "accepted"}, nil},
+	}
+	for _, tt := range tests {
+		f := field.Field{Kind: "bool", Value: tt.value, FieldName: "Terms"}
+		valsErrs, err := f.CallValidator(tt.rules)
+		if err != nil {
+			t.Fatalf("expected not return an error but got: %v", err)
+		}
+		if !reflect.DeepEqual(valsErrs, tt.expected) {
+			t.Errorf("%v %v: should return the errors: %q, but got %q", tt.value, tt.rules, tt.expected, valsErrs)
+		}
+	}
+}
+
+func TestField_Validate_shouldValidateDurations(t *testing.T) {
+	tests := []struct {
+		value    time.Duration
+		rules    []string
+		expected []string
+	}{
+		{100 * time.Millisecond, []string{"min=500ms", "max=1h30m"}, []string{"the field Timeout should be at least 500ms"}},
+		{2 * time.Hour, []string{"min=500ms", "max=1h30m"}, []string{"the field Timeout should be at most 1h30m"}},
+		{0, []string{"required", "nonzero", "max=1.5h"}, []string{"the field Timeout should not be zero"}},
+		{time.Minute, []string{"required", "min=0", "max=1.5h"}, nil},
+	}
+	for _, tt := range tests {
+		f := field.Field{Kind: "time.Duration", Value: tt.value, FieldName: "Timeout"}
+		valsErrs, err := f.CallValidator(tt.rules)
+		if err != nil {
+			t.Fatalf("expected not return an error but got: %v", err)
+		}
+		if !reflect.DeepEqual(valsErrs, tt.expected) {
+			t.Errorf("%v %v: should return the errors: %q, but got %q", tt.value, tt.rules, tt.expected, valsErrs)
+		}
+	}
+}
+
+func TestField_Validate_shouldValidateTimes(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		value    time.Time
+		rules    []string
+		expected []string
+	}{
+		{now.AddDate(-17, 0, 0), []string{"before=now-18y"}, []string{"the field BirthDate should be before now-18y"}},
+		{now.AddDate(-19, 0, 0), []string{"before=now-18y", "after=1900-01-01"}, nil},
+		{time.Date(1899, 12, 31, 0, 0, 0, 0, time.UTC), []string{"after=1900-01-01"},
+			[]string{"the field BirthDate should be after 1900-01-01"}},
+		{now.Add(-time.Hour), []string{"after=now-1d+2h30m"}, nil},
+		{now.Add(-23 * time.Hour), []string{"after=now-1d2h"}, nil},
+		{now.AddDate(0, -2, 0), []string{"after=now-1mo2w"}, []string{"the field BirthDate should be after now-1mo2w"}},
+		{time.Date(2024, 8, 31, 0, 0, 0, 0, time.UTC), []string{"between=2024-07-01 2024-08-31"}, nil},
+		{time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC), []string{"between=2024-07-01T00:00:00Z 2024-08-31"},
+			[]string{"the field BirthDate should be between 2024-07-01T00:00:00Z and 2024-08-31"}},
+		{time.Time{}, []string{"required", "before=now"}, []string{"the field BirthDate should not be empty"}},
+	}
+	for _, tt := range tests {
+		f := field.Field{Kind: "time.Time", Value: tt.value, FieldName: "BirthDate"}
+		valsErrs, err := f.CallValidator(tt.rules)
+		if err != nil {
+			t.Fatalf("expected not return an error but got: %v", err)
+		}
+		if !reflect.DeepEqual(valsErrs, tt.expected) {
+			t.Errorf("%v %v: should return the errors: %q, but got %q", tt.value, tt.rules, tt.expected, valsErrs)
+		}
+	}
+}
+
+func TestCompile_shouldReturnErrorForInvalidTimesAndDurations(t *testing.T) {
+	for _, rule := range []string{"before=yesterday", "after=now-18", "after=now18y", "before=now-1.5y", "before=now-1q",
+		"between=2024-01-01", "required=yes"} {
+		if _, err := field.Compile("time.Time", time.Time{}, []string{rule}); err == nil {
+			t.Errorf("%s: expected to return an error but got nil", rule)
+		}
+	}
+	for _, rule := range []string{"min=10", "max=1 hour"} {
+		if _, err := field.Compile("time.Duration", time.Duration(0), []string{rule}); err == nil {
+			t.Errorf("%s: expected to return an error but got nil", rule)
+		}
+	}
+}
+
+type demoCode string
+
+func TestField_Validate_shouldValidateNamedTypes(t *testing.T) {
+	f := field.Field{Kind: "field_test.demoCode", Value: demoCode("ab"), FieldName: "Code"}
+	valsErrs, err := f.CallValidator([]string{"min=3", "prefix=x"})
+	if err != nil {
+		t.Fatalf("expected not return an error but got: %v", err)
+	}
+	expected := []string{"the field Code should contains at least 3 characters", "the field Code should start with x"}
+	if !reflect.DeepEqual(valsErrs, expected) {
+		t.Errorf("should return the errors: %q, but got %q", expected, valsErrs)
+	}
+	f = field.Field{Kind: "complex128", Value: complex(1, 2), FieldName: "Code"}
+	if _, err := f.CallValidator([]string{"min=3"}); err == nil {
+		t.Error("expected to return an error for a not supported kind but got nil")
+	}
+}
+
+type demoDecimal struct {
+	unscaled int64
+	scale    int64
+}
+
+func (d demoDecimal) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(big.NewInt(d.unscaled), new(big.Int).Exp(big.NewInt(10), big.NewInt(d.scale), nil))
+}
+
+func TestField_Validate_shouldCompareNumbersExactly(t *testing.T) {
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	tests := []struct {
+		value    interface{}
+		rules    []string
+		expected []string
+	}{
+		{int64(9007199254740993), []string{"max=9007199254740992"}, []string{"the field Amount should be less than 9007199254740992"}},
+		{uint64(18446744073709551615), []string{"max=18446744073709551614"}, []string{"the field Amount should be less than 18446744073709551614"}},
+		{uint64(18446744073709551615), []string{"min=18446744073709551615"}, nil},
+		{0, []string{"min=0.5"}, []string{"the field Amount should be grater than 0.5"}},
+		{1, []string{"min=0.5", "max=1.5"}, nil},
+		{int8(-3), []string{"max=-2.5"}, nil},
+		{float32(0.1), []string{"min=0.1", "max=0.1"}, nil},
+		{0.30000000000000004, []string{"max=0.3"}, []string{"the field Amount should be less than 0.3"}},
+		{*huge, []string{"max=123456789012345678901234567889"}, []string{"the field Amount should be less than 123456789012345678901234567889"}},
+		{*big.NewFloat(0.1), []string{"max=0.1"}, nil},
+		{*new(big.Float).SetInf(false), []string{"max=1e100"}, []string{"the field Amount should be less than 1e100"}},
+		{*big.NewRat(1, 3), []string{"min=0.33", "max=1/3"}, nil},
+		{demoDecimal{1999, 2}, []string{"required", "min=19.99", "max=20"}, nil},
+		{demoDecimal{1998, 2}, []string{"min=19.99"}, []string{"the field Amount should be grater than 19.99"}},
+		{big.Int{}, []string{"required", "nonzero"}, []string{"the field Amount should not be zero"}},
+	}
+	for _, tt := range tests {
+		f := field.Field{Kind: reflect.TypeOf(tt.value).String(), Value: tt.value, FieldName: "Amount"}
+		valsErrs, err := f.CallValidator(tt.rules)
+		if err != nil {
+			t.Fatalf("expected not return an error but got: %v", err)
+		}
+		if !reflect.DeepEqual(valsErrs, tt.expected) {
+			t.Errorf("%v %v: should return the errors: %q, but got %q", tt.value, tt.rules, tt.expected, valsErrs)
+		}
+	}
+	if _, err := field.Compile("int", 0, []string{"min=ten"}); err == nil {
+		t.Error("expected to return an error for an invalid bound but got nil")
+	}
+}
+
+func TestField_Validate_shouldApplyNegativeAndExclusiveBounds(t *testing.T) {
+	tests := []struct {
+		value    interface{}
+		rules    []string
+		expected []string
+	}{
+		{-20, []string{"min=-10", "max=-1"}, []string{"the field Temperature should be grater than -10"}},
+		{0, []string{"min=-10", "max=-1"}, []string{"the field Temperature should be less than -1"}},
+		{-5.5, []string{"min=-10", "max=-1"}, nil},
+		{10, []string{"gt=10", "lt=10"},
+			[]string{"the field Temperature should be greater than 10", "the field Temperature should be less than 10"}},
+		{10, []string{"gte=10", "lte=10"}, nil},
+		{int8(-128), []string{"gte=-127.5"}, []string{"the field Temperature should be greater than or equal to -127.5"}},
+		{uint(0), []string{"gt=-1", "lte=-1"}, []string{"the field Temperature should be less than or equal to -1"}},
+		{-time.Minute, []string{"gt=-1m", "lt=0"}, []string{"the field Temperature should be longer than -1m"}},
+		{time.Hour, []string{"gte=1h", "lt=1h"}, []string{"the field Temperature should be shorter than 1h"}},
+	}
+	for _, tt := range tests {
+		f := field.Field{Kind: reflect.TypeOf(tt.value).String(), Value: tt.value, FieldName: "Temperature"}
+		valsErrs, err := f.CallValidator(tt.rules)
+		if err != nil {
+			t.Fatalf("expected not return an error but got: %v", err)
+		}
+		if !reflect.DeepEqual(valsErrs, tt.expected) {
+			t.Errorf("%v %v: should return the errors: %q, but got %q", tt.value, tt.rules, tt.expected, valsErrs)
+		}
+	}
+	for _, rule := range []string{"min=-1", "max=-1", "minbytes=-1", "maxbytes=-2"} {
+		if _, err := field.Compile("string", "", []string{rule}); err == nil {
+			t.Errorf("%s: expected to return an error for a negative count but got nil", rule)
+		}
+	}
+	if _, err := field.Compile("[]string", []string{}, []string{"max=-1"}); err == nil {
+		t.Error("expected to return an error for a negative count but got nil")
+	}
+}
+
+func TestField_Validate_shouldApplyTheNumericRules(t *testing.T) {
+	nan, inf := math.NaN(), math.Inf(1)
+	tests := []struct {
+		value    interface{}
+		rules    []string
+		expected []string
+	}{
+		{15, []string{"multiple_of=5", "step=10"}, []string{"the field Amount should be a multiple of 10"}},
+		{0.3, []string{"multiple_of=0.1"}, nil},
+		{float32(0.35), []string{"step=0.1"}, []string{"the field Amount should be a multiple of 0.1"}},
+		{uint64(18446744073709551615), []string{"multiple_of=5"}, nil},
+		{5, []string{"oneof=1 5 10"}, nil},
+		{2.5, []string{"oneof=1 2 3"}, []string{"the field Amount should be one of 1, 2, 3"}},
+		{0, []string{"positive", "negative", "nonzero"},
+			[]string{"the field Amount should be positive", "the field Amount should be negative", "the field Amount should not be zero"}},
+		{int8(-1), []string{"negative", "nonzero"}, nil},
+		{nan, []string{"finite", "min=0", "max=10", "oneof=1 2", "positive", "nonzero", "required"}, []string{
+			"the field Amount should be a finite number",
+			"the field Amount should be grater than 0",
+			"the field Amount should be less than 10",
+			"the field Amount should be one of 1, 2",
+			"the field Amount should be positive",
+		}},
+		{inf, []string{"finite", "multiple_of=1"},
+			[]string{"the field Amount should be a finite number", "the field Amount should be a multiple of 1"}},
+		{19.99, []string{"finite", "decimals=2", "digits=4"}, nil},
+		{19.999, []string{"decimals=2", "digits=4"}, []string{
+			"the field Amount should contains max 2 decimal places",
+			"the field Amount should contains max 4 significant digits",
+		}},
+		{1200, []string{"decimals=0", "digits=2"}, nil},
+		{*big.NewRat(1, 3), []string{"decimals=10"}, []string{"the field Amount should contains max 10 decimal places"}},
+		{demoDecimal{-12345, 4}, []string{"decimals=4", "digits=5", "negative", "multiple_of=0.0005"}, nil},
+	}
+	for _, tt := range tests {
+		f := field.Field{Kind: reflect.TypeOf(tt.value).String(), Value: tt.value, FieldName: "Amount"}
+		valsErrs, err := f.CallValidator(tt.rules)
+		if err != nil {
+			t.Fatalf("expected not return an error but got: %v", err)
+		}
+		if !reflect.DeepEqual(valsErrs, tt.expected) {
+			t.Errorf("%v %v: should return the errors: %q, but got %q", tt.value, tt.rules, tt.expected, valsErrs)
+		}
+	}
+	for _, rule := range []string{"multiple_of=0", "step=x", "oneof=1 two", "decimals=-1", "digits=x", "positive=yes"} {
+		if _, err := field.Compile("int", 0, []string{rule}); err == nil {
+			t.Errorf("%s: expected to return an error but got nil", rule)
+		}
+	}
+}
+
+func TestField_Validate_shouldSkipTheRulesOfEmptyFieldsByOmitempty(t *testing.T) {
+	tests := []struct {
+		f        field.Field
+		rules    []string
+		expected []string
+	}{
+		{field.Field{Kind: "int", Value: 0}, []string{"omitempty", "min=5", "oneof=5 10"}, nil},
+		{field.Field{Kind: "int", Value: 0}, []string{"omitempty", "required", "min=5"}, nil},
+		{field.Field{Kind: "int", Value: 0, Nil: true}, []string{"omitempty", "required", "min=5"},
+			[]string{"the field Code should not be empty"}},
+		{field.Field{Kind: "string", Value: ""}, []string{"omitempty", "required", "min=5"},
+			[]string{"the field Code should not be empty"}},
+		{field.Field{Kind: "string", Value: ""}, []string{"omitempty", "email", "min=5"}, nil},
+		{field.Field{Kind: "string", Value: "ab"}, []string{"omitempty", "min=5"},
+			[]string{"the field Code should contains at least 5 characters"}},
+		{field.Field{Kind: "[]int", Value: []int{}}, []string{"omitempty", "min=2"}, nil},
+		{field.Field{Kind: "time.Time", Value: time.Time{}}, []string{"omitempty", "after=now"}, nil},
+		{field.Field{Kind: "time.Time", Value: time.Time{}}, []string{"nonzero"}, []string{"the field Code should not be zero"}},
+		{field.Field{Kind: "[]int", Value: []int{}}, []string{"nonzero"}, []string{"the field Code should not be empty"}},
+		{field.Field{Kind: "string", Value: ""}, []string{"nonzero"}, []string{"the field Code should not be empty"}},
+		{field.Field{Kind: "int", Value: 0, Nil: true}, []string{"required", "checknil=true", "min=-1"},
+			[]string{"the field Code should not be empty"}},
+	}
+	for _, tt := range tests {
+		tt.f.FieldName = "Code"
+		valsErrs, err := tt.f.CallValidator(tt.rules)
+		if err != nil {
+			t.Fatalf("expected not return an error but got: %v", err)
+		}
+		if !reflect.DeepEqual(valsErrs, tt.expected) {
+			t.Errorf("%v %v: should return the errors: %q, but got %q", tt.f.Value, tt.rules, tt.expected, valsErrs)
+		}
+	}
+	if _, err := field.Compile("int", 0, []string{"omitempty=maybe"}); err == nil {
+		t.Error("expected to return an error for an invalid omitempty but got nil")
+	}
+}
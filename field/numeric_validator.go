@@ -1,101 +1,190 @@
 package field
 
 import (
+	"errors"
+	"math"
+	"math/big"
+	"reflect"
 	"strconv"
+	"strings"
 )
 
+// Decimal describes a decimal number type, e.g. the type of a decimal package, which is validated by the
+// numeric rules. The Rat function returns the exact value of the number.
+type Decimal interface {
+	Rat() *big.Rat
+}
+
+// The types of the math/big numbers and of the Decimal interface.
+var (
+	bigIntType   = reflect.TypeOf(big.Int{})
+	bigFloatType = reflect.TypeOf(big.Float{})
+	bigRatType   = reflect.TypeOf(big.Rat{})
+	decimalType  = reflect.TypeOf((*Decimal)(nil)).Elem()
+)
+
+// boundRules contains the rules which compare the value of the field with a bound, and the valid results of the
+// comparison. The min and max rules are inclusive, the same way as the gte and lte rules.
+var boundRules = map[string]func(c int) bool{
+	"min": func(c int) bool { return c >= 0 },
+	"gte": func(c int) bool { return c >= 0 },
+	"gt":  func(c int) bool { return c > 0 },
+	"max": func(c int) bool { return c <= 0 },
+	"lte": func(c int) bool { return c <= 0 },
+	"lt":  func(c int) bool { return c < 0 },
+}
+
+// numericMessages contains the default messages of the bound rules of the numeric validator after the field name.
+var numericMessages = map[string]string{
+	"min": "should be grater than ",
+	"gte": "should be greater than or equal to ",
+	"gt":  "should be greater than ",
+	"max": "should be less than ",
+	"lte": "should be less than or equal to ",
+	"lt":  "should be less than ",
+}
+
+// numericFlags contains the rules of the numeric validator which are enabled by `=true` e.g. `validate:"positive"`,
+// and their default messages after the field name.
+var numericFlags = map[string]string{
+	"positive": "should be positive",
+	"negative": "should be negative",
+	"nonzero":  "should not be zero",
+	"finite":   "should be a finite number",
+}
+
 // Numeric struct describes the validator for numeric values. A numeric validator is
 // responsible to define the rules of validation and validate a numeric property.
+//
+// The integers are compared with the bounds by exact integer arithmetic, so the int64 and uint64 values beyond
+// 2^53 are compared correctly, and the floats with the float nearest to the bound, of the same precision, so
+// a float32 0.1 satisfies the rule max=0.1. The math/big numbers and the Decimal values are compared exactly.
+// Any bound can be negative e.g. `validate:"gte=-273.15"`.
+//
+// The multiple_of, step, decimals and digits rules apply to the decimal value of the floats, which is the shortest
+// decimal that represents the float e.g. 0.1, so a float64 0.3 is a multiple of 0.1. A NaN value does not
+// satisfy any rule which compares it with a number, and the finite rule rejects the NaN and the infinite values.
 type numeric struct {
 	// valy.Field embedded to Numeric validator to have access to Field's properties.
-	Field
-
-	// min defines the min value.
-	min float64
+	*Field
 
-	// max defines the max value.
-	max float64
+	// bounds defines the bounds of the boundRules by rule name e.g. bounds["min"].
+	bounds map[string]*numberBound
 
-	// require defines if the field has to be set.
+	// require defines if the field has to be provided, so it is not nil. A zero value is provided.
 	required bool
 
-	// value is the value of the field.
-	value float64
+	// flags defines which of the numericFlags rules are enabled e.g. flags["positive"] = true.
+	flags map[string]bool
+
+	// multiples defines the number the field has to be a multiple of, by the rule name multiple_of or step.
+	multiples map[string]*big.Rat
+
+	// oneof defines the values that the field can have.
+	oneof []*numberBound
+
+	// decimals defines the max decimal places of the field.
+	decimals int
+
+	// digits defines the max significant digits of the field.
+	digits int
+
+	// nan defines if the value of the field is NaN.
+	nan bool
+
+	// rat is the exact decimal value of the field, see ratOf. It is computed by the first rule which needs it.
+	rat *big.Rat
+
+	// hasRat defines if the rat is computed.
+	hasRat bool
 }
 
 // NewNumeric initializes and returns a Numeric.
 func newNumeric(fp *Field) *numeric {
 	nv := &numeric{
-		min:      -1,
-		max:      -1,
 		required: false,
 	}
-	nv.Field = *fp
+	nv.Field = fp
 	return nv
 }
 
+// with returns a copy of the validator, with the parsed rules, for the field fp.
+func (n *numeric) with(fp *Field) validator {
+	nv := *n
+	nv.Field = fp
+	return &nv
+}
+
 // Validate is responsible to validate this field. After this call
 // the function will return the errors if the field is invalid.
-func (n *numeric) validate() ([]string, error) {
-	v := n.convertToFloat64(n.Value)
-	n.value = v
-	err := n.setRules(n.Rules)
-	if err != nil {
-		return nil, err
-	}
-	if n.min > -1 {
-		n.minRule()
-	}
-	if n.max > -1 {
-		n.maxRule()
-	}
-	if n.required {
-		n.requiredRule()
+func (n *numeric) validate() ([]Error, error) {
+	n.nan = isNaN(n.Value)
+	n.rat, n.hasRat = nil, false
+	// Apply the rules in the order of the annotation.
+	for _, r := range n.Rules {
+		switch {
+		case n.bounds[r.Name] != nil:
+			n.boundRule(r.Name)
+		case r.Name == "required" && n.required:
+			n.requiredRule()
+		case n.flags[r.Name]:
+			n.flagRule(r.Name)
+		case n.multiples[r.Name] != nil:
+			n.multipleRule(r.Name)
+		case r.Name == "oneof" && n.oneof != nil:
+			n.oneofRule()
+		case r.Name == "decimals":
+			n.decimalsRule()
+		case r.Name == "digits":
+			n.digitsRule()
+		}
 	}
 	return n.Errs, nil
 }
 
-// convertToFloat64 converts the field value to fload64 in order to be able to apply the rules.
-func (numeric) convertToFloat64(i interface{}) float64 {
-	switch s := i.(type) {
-	case float32:
-		return float64(s)
-	case int:
-		return float64(s)
-	case int64:
-		return float64(s)
-	case int32:
-		return float64(s)
-	case int16:
-		return float64(s)
-	case int8:
-		return float64(s)
-	case uint:
-		return float64(s)
-	case uint64:
-		return float64(s)
-	case uint32:
-		return float64(s)
-	case uint16:
-		return float64(s)
-	case uint8:
-		return float64(s)
-	default:
-		return s.(float64)
-	}
-}
-
 // setRules sets the rules for the current field.
-func (n *numeric) setRules(rules map[string]string) error {
+func (n *numeric) setRules(rules Rules) error {
 	var err error
-	for k, v := range rules {
-		switch k {
-		case "min":
-			n.min, err = strconv.ParseFloat(v, 64)
-		case "max":
-			n.max, err = strconv.ParseFloat(v, 64)
-		case "required":
-			n.required, err = strconv.ParseBool(v)
+	for _, r := range rules {
+		switch {
+		case boundRules[r.Name] != nil:
+			if n.bounds == nil {
+				n.bounds = make(map[string]*numberBound)
+			}
+			n.bounds[r.Name], err = parseNumberBound(r.Name, r.Param)
+		case r.Name == "required":
+			n.required, err = strconv.ParseBool(r.Param)
+		case numericFlags[r.Name] != "":
+			if n.flags == nil {
+				n.flags = make(map[string]bool)
+			}
+			n.flags[r.Name], err = strconv.ParseBool(r.Param)
+		case r.Name == "multiple_of" || r.Name == "step":
+			var b *numberBound
+			if b, err = parseNumberBound(r.Name, r.Param); err == nil && b.rat.Sign() == 0 {
+				err = errors.New("the rule " + r.Name + " should not be zero")
+			}
+			if err == nil {
+				if n.multiples == nil {
+					n.multiples = make(map[string]*big.Rat)
+				}
+				n.multiples[r.Name] = b.rat
+			}
+		case r.Name == "oneof":
+			n.oneof = []*numberBound{}
+			for _, v := range strings.Fields(r.Param) {
+				b, err := parseNumberBound(r.Name, v)
+				if err != nil {
+					return err
+				}
+				n.oneof = append(n.oneof, b)
+			}
+		case r.Name == "decimals":
+			n.decimals, err = parseCount(r.Name, r.Param)
+		case r.Name == "digits":
+			n.digits, err = parseCount(r.Name, r.Param)
+		default:
+			err = n.kindRuleError(r.Name)
 		}
 		if err != nil {
 			return err
@@ -104,25 +193,288 @@ func (n *numeric) setRules(rules map[string]string) error {
 	return nil
 }
 
-// minRule checks if field is less than the min value.
-func (n *numeric) minRule() {
-	if n.value < n.min {
-		n.Errs = append(n.Errs, "the field "+n.FieldName+" should be grater than "+
-			strconv.Itoa(int(n.min)))
+// boundRule checks if field satisfies the bound of the rule e.g. it is not less than the min value.
+func (n *numeric) boundRule(rule string) {
+	b := n.bounds[rule]
+	if n.nan || !boundRules[rule](compareNumber(n.Value, b)) {
+		n.fail(rule, "the field "+n.FieldName+" "+numericMessages[rule]+b.s)
 	}
 }
 
-// maxRule checks if field is bigger than the max value.
-func (n *numeric) maxRule() {
-	if n.value > n.max {
-		n.Errs = append(n.Errs, "the field "+n.FieldName+" should be less than "+
-			strconv.Itoa(int(n.max)))
+// requiredRule check if field is provided. The zero is a valid value, the nonzero rule rejects it.
+func (n *numeric) requiredRule() {
+	if n.Nil {
+		n.fail("required", "the field "+n.FieldName+" should not be empty")
 	}
 }
 
-// requiredRule check if field is defined.
-func (n *numeric) requiredRule() {
-	if n.value == 0 {
-		n.Errs = append(n.Errs, "the field "+n.FieldName+" should not be empty")
+// flagRule checks if field satisfies the rule of the numericFlags e.g. it is positive.
+func (n *numeric) flagRule(rule string) {
+	var valid bool
+	switch rule {
+	case "positive":
+		valid = !n.nan && signOf(n.Value) > 0
+	case "negative":
+		valid = !n.nan && signOf(n.Value) < 0
+	case "nonzero":
+		valid = n.nan || signOf(n.Value) != 0
+	case "finite":
+		valid = !n.nan && n.decimal() != nil
+	}
+	if !valid {
+		n.fail(rule, "the field "+n.FieldName+" "+numericFlags[rule])
+	}
+}
+
+// multipleRule checks if field is a multiple of the number of the multiple_of or step rule.
+func (n *numeric) multipleRule(rule string) {
+	if r := n.decimal(); r == nil || !new(big.Rat).Quo(r, n.multiples[rule]).IsInt() {
+		n.fail(rule, "the field "+n.FieldName+" should be a multiple of "+n.Rules.Get(rule))
+	}
+}
+
+// oneofRule checks if field is one of the space separated numbers e.g. oneof='1 5 10'.
+func (n *numeric) oneofRule() {
+	values := make([]string, len(n.oneof))
+	for i, b := range n.oneof {
+		if !n.nan && compareNumber(n.Value, b) == 0 {
+			return
+		}
+		values[i] = b.s
+	}
+	n.fail("oneof", "the field "+n.FieldName+" should be one of "+strings.Join(values, ", "))
+}
+
+// decimalsRule checks if field contains more decimal places than the decimals rule.
+func (n *numeric) decimalsRule() {
+	if places, _, ok := decimalDigits(n.decimal()); !ok || places > n.decimals {
+		n.fail("decimals", "the field "+n.FieldName+" should contains max "+strconv.Itoa(n.decimals)+" decimal places")
+	}
+}
+
+// digitsRule checks if field contains more significant digits than the digits rule.
+func (n *numeric) digitsRule() {
+	if _, digits, ok := decimalDigits(n.decimal()); !ok || digits > n.digits {
+		n.fail("digits", "the field "+n.FieldName+" should contains max "+strconv.Itoa(n.digits)+" significant digits")
+	}
+}
+
+// decimal returns the exact decimal value of the field, or nil if it is NaN or infinite.
+func (n *numeric) decimal() *big.Rat {
+	if !n.hasRat {
+		n.rat, n.hasRat = ratOf(n.Value), true
+	}
+	return n.rat
+}
+
+// numberBound describes a bound of the numeric rules, which is parsed exactly from the param of the rule
+// e.g. "0.5", "-12" or "18446744073709551615".
+type numberBound struct {
+	// s is the bound as it is defined in the annotation.
+	s string
+
+	// rat is the exact value of the bound.
+	rat *big.Rat
+
+	// i is the value of the bound if it is an integer of the int64 range.
+	i     int64
+	isInt bool
+
+	// u is the value of the bound if it is an integer of the uint64 range.
+	u      uint64
+	isUint bool
+
+	// f64 and f32 are the float64 and float32 nearest to the bound.
+	f64 float64
+	f32 float32
+}
+
+// parseNumberBound parses the bound s of the rule.
+func parseNumberBound(rule, s string) (*numberBound, error) {
+	rat, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, errors.New("Invalid number `" + s + "` of the rule " + rule)
+	}
+	b := &numberBound{s: s, rat: rat}
+	if rat.IsInt() {
+		num := rat.Num()
+		b.i, b.isInt = num.Int64(), num.IsInt64()
+		b.u, b.isUint = num.Uint64(), num.IsUint64()
+	}
+	b.f64, _ = rat.Float64()
+	b.f32, _ = rat.Float32()
+	return b, nil
+}
+
+// compareNumber returns -1, 0 or +1 if the number value is less than, equal to or greater than the bound b.
+// The value is an integer, a float, a math/big number or a Decimal, of any named type.
+func compareNumber(value interface{}, b *numberBound) int {
+	v := reflect.ValueOf(value)
+	switch k := v.Kind(); {
+	case isInt(k) && b.isInt:
+		return compareOrdered(v.Int(), b.i)
+	case isInt(k):
+		return new(big.Rat).SetInt64(v.Int()).Cmp(b.rat)
+	case isUint(k) && b.isUint:
+		return compareOrdered(v.Uint(), b.u)
+	case isUint(k):
+		return new(big.Rat).SetInt(new(big.Int).SetUint64(v.Uint())).Cmp(b.rat)
+	case k == reflect.Float32:
+		return compareOrdered(v.Float(), float64(b.f32))
+	case k == reflect.Float64:
+		return compareOrdered(v.Float(), b.f64)
+	}
+	switch x := pointerOf(value).(type) {
+	case *big.Int:
+		return new(big.Rat).SetInt(x).Cmp(b.rat)
+	case *big.Rat:
+		return x.Cmp(b.rat)
+	case *big.Float:
+		if x.IsInf() {
+			return x.Sign()
+		}
+		// Compare with the float of the same precision which is nearest to the bound.
+		return x.Cmp(new(big.Float).SetPrec(x.Prec()).SetRat(b.rat))
+	}
+	return decimalOf(value).Rat().Cmp(b.rat)
+}
+
+// signOf returns -1, 0 or +1 if the number value is negative, zero or positive.
+func signOf(value interface{}) int {
+	v := reflect.ValueOf(value)
+	switch k := v.Kind(); {
+	case isInt(k):
+		return compareOrdered(v.Int(), 0)
+	case isUint(k):
+		return compareOrdered(v.Uint(), 0)
+	case k == reflect.Float32 || k == reflect.Float64:
+		return compareOrdered(v.Float(), 0)
+	}
+	switch x := pointerOf(value).(type) {
+	case *big.Int:
+		return x.Sign()
+	case *big.Rat:
+		return x.Sign()
+	case *big.Float:
+		return x.Sign()
+	}
+	return decimalOf(value).Rat().Sign()
+}
+
+// ratOf returns the exact decimal value of the number value, or nil if it is NaN or infinite. The value of
+// a float is the shortest decimal which represents it e.g. 0.1, instead of its exact binary value.
+func ratOf(value interface{}) *big.Rat {
+	v := reflect.ValueOf(value)
+	switch k := v.Kind(); {
+	case isInt(k):
+		return new(big.Rat).SetInt64(v.Int())
+	case isUint(k):
+		return new(big.Rat).SetInt(new(big.Int).SetUint64(v.Uint()))
+	case k == reflect.Float32 || k == reflect.Float64:
+		f := v.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil
+		}
+		r, _ := new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, v.Type().Bits()))
+		return r
+	}
+	switch x := pointerOf(value).(type) {
+	case *big.Int:
+		return new(big.Rat).SetInt(x)
+	case *big.Rat:
+		return new(big.Rat).Set(x)
+	case *big.Float:
+		if x.IsInf() {
+			return nil
+		}
+		r, _ := new(big.Rat).SetString(x.Text('g', -1))
+		return r
 	}
+	return decimalOf(value).Rat()
+}
+
+// pointerOf returns a pointer to the math/big number value e.g. the *big.Int of a big.Int, so their functions
+// with pointer receivers can be called. The rest values, and the pointers, are returned as they are.
+func pointerOf(value interface{}) interface{} {
+	switch x := value.(type) {
+	case big.Int:
+		return &x
+	case big.Rat:
+		return &x
+	case big.Float:
+		return &x
+	}
+	return value
+}
+
+// isNaN reports whether the number value is a NaN float.
+func isNaN(value interface{}) bool {
+	v := reflect.ValueOf(value)
+	return (v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64) && math.IsNaN(v.Float())
+}
+
+// decimalDigits returns the decimal places and the significant digits of the number r e.g. 2 and 3 for 1.25.
+// The trailing zeros of an integer are not significant, so 1200 has 2 significant digits. It returns false if r
+// is nil or it has not a finite decimal representation e.g. 1/3.
+func decimalDigits(r *big.Rat) (int, int, bool) {
+	if r == nil {
+		return 0, 0, false
+	}
+	// The denominator of a finite decimal is 2^a * 5^b, so it has max(a, b) decimal places.
+	den := new(big.Int).Set(r.Denom())
+	two, five, mod := big.NewInt(2), big.NewInt(5), new(big.Int)
+	var twos, fives int
+	for ; mod.Mod(den, two).Sign() == 0; twos++ {
+		den.Quo(den, two)
+	}
+	for ; mod.Mod(den, five).Sign() == 0; fives++ {
+		den.Quo(den, five)
+	}
+	if den.Cmp(big.NewInt(1)) != 0 {
+		return 0, 0, false
+	}
+	places := twos
+	if fives > places {
+		places = fives
+	}
+	unscaled := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(places)), nil)
+	unscaled.Mul(unscaled, r.Num()).Quo(unscaled, r.Denom()).Abs(unscaled)
+	if unscaled.Sign() == 0 {
+		return places, 0, true
+	}
+	return places, len(strings.TrimRight(unscaled.String(), "0")), true
+}
+
+// decimalOf returns the Decimal of the value. If the Rat function has a pointer receiver, it is called
+// on a copy of the value.
+func decimalOf(value interface{}) Decimal {
+	if d, ok := value.(Decimal); ok {
+		return d
+	}
+	v := reflect.New(reflect.TypeOf(value))
+	v.Elem().Set(reflect.ValueOf(value))
+	return v.Interface().(Decimal)
+}
+
+// isNumberType reports whether the struct type t is a math/big number or a Decimal, which are validated
+// by the numeric rules.
+func isNumberType(t reflect.Type) bool {
+	return t == bigIntType || t == bigFloatType || t == bigRatType ||
+		t.Implements(decimalType) || reflect.PtrTo(t).Implements(decimalType)
+}
+
+// isNumberValue reports whether the type t of a field's value is a math/big number or a Decimal, or a pointer
+// to one of them.
+func isNumberValue(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct && isNumberType(t)
+}
+
+// IsValueType reports whether the struct type t is validated as a single value by the rules of the field,
+// instead of by the rules of its fields. The time.Time values, the math/big numbers and the Decimal values
+// are validated as single values.
+func IsValueType(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && (t == timeType || isNumberType(t))
 }
@@ -0,0 +1,196 @@
+package field
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+)
+
+// builtinRules contains the names of the rules which are handled by the validators
+// and can not be registered as custom rules.
+var builtinRules = map[string]bool{
+	"Err":       true,
+	"required":  true,
+	"min":       true,
+	"max":       true,
+	"gt":        true,
+	"gte":       true,
+	"lt":        true,
+	"lte":       true,
+	"unique":    true,
+	"checknil":  true,
+	"omitempty": true,
+	"dive":      true,
+	"keys":      true,
+	"endkeys":   true,
+
+	"email":        true,
+	"url":          true,
+	"uuid":         true,
+	"hostname":     true,
+	"ip":           true,
+	"ipv4":         true,
+	"ipv6":         true,
+	"cidr":         true,
+	"hex":          true,
+	"base64":       true,
+	"alpha":        true,
+	"alphanumeric": true,
+	"prefix":       true,
+	"suffix":       true,
+	"contains":     true,
+	"oneof":        true,
+	"regex":        true,
+	"pattern":      true,
+	"count":        true,
+	"minbytes":     true,
+	"maxbytes":     true,
+	"normalized":   true,
+	"eqfield":      true,
+	"nefield":      true,
+	"gtfield":      true,
+	"gtefield":     true,
+	"ltfield":      true,
+	"ltefield":     true,
+	"accepted":     true,
+	"before":       true,
+	"after":        true,
+	"between":      true,
+	"positive":     true,
+	"negative":     true,
+	"nonzero":      true,
+	"finite":       true,
+	"multiple_of":  true,
+	"step":         true,
+	"decimals":     true,
+	"digits":       true,
+
+	"required_if":          true,
+	"required_unless":      true,
+	"required_with":        true,
+	"required_with_all":    true,
+	"required_without":     true,
+	"required_without_all": true,
+	"when":                 true,
+	"unless":               true,
+}
+
+// genericRules contains the builtin rules which are applied to the fields of any kind, instead of by the
+// validator of the field's kind. The cross-field rules and the conditional requirement rules are generic too.
+var genericRules = map[string]bool{
+	"checknil":  true,
+	"omitempty": true,
+	"dive":      true,
+	"keys":      true,
+	"endkeys":   true,
+	"when":      true,
+	"unless":    true,
+}
+
+// isKindRule reports whether the rule name is a builtin rule which is applied by the validator of the field's kind.
+func isKindRule(name string) bool {
+	_, isCross := crossRules[name]
+	return builtinRules[name] && !genericRules[name] && !conditionalRules[name] && !isCross
+}
+
+// kindRuleError returns the error of the builtin rule name which is not applied by the validator of the
+// field's kind e.g. the email rule of an int field. It returns nil for the custom rules and the generic rules.
+func (fp *Field) kindRuleError(name string) error {
+	if !isKindRule(name) {
+		return nil
+	}
+	return errors.New("the rule " + name + " can not be applied to the " + fp.Kind + " field")
+}
+
+// Check describes the input of a custom rule.
+//
+// For example, if you have the following struct:
+//
+//	struct Product {
+//	  SKU string `validate:"required=true,sku=EU"`
+//	}
+//
+// p := &Product{"EU-1234"}
+//
+// The sku rule will be called with the Check:
+//
+// FieldName = "SKU"
+// Value = "EU-1234"
+// Param = "EU"
+// Parent = Product{"EU-1234"}
+type Check struct {
+	// FieldName is the path of the field e.g. "Address.PostCode"
+	FieldName string
+
+	// Value is the actual value of the field
+	Value interface{}
+
+	// Param is the argument of the rule e.g. for the annotation `validate:"sku=EU"` the Param = "EU"
+	Param string
+
+	// Parent is the struct which contains the field.
+	Parent interface{}
+
+	// Context is the context of the validation. The rules which call a database or a service should
+	// honor its deadline and cancellation, and it can contain request-scoped values e.g. the tenant.
+	Context context.Context
+}
+
+// RuleFunc describes a custom rule. It returns false if the value of the field is not valid.
+// If the rule can not be checked, e.g. for an invalid Param, it returns an error which stops
+// the validation.
+type RuleFunc func(c Check) (bool, error)
+
+// Registry contains the custom rules by name. It is safe for concurrent use.
+type Registry struct {
+	mu    sync.RWMutex
+	rules map[string]RuleFunc
+
+	// parent contains the rules which are not defined by the Registry.
+	parent *Registry
+}
+
+// NewRegistry initializes and returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{rules: make(map[string]RuleFunc)}
+}
+
+// Extend initializes and returns an empty Registry which falls back to the rules of r.
+// The rules registered to the returned Registry are not visible to r.
+func (r *Registry) Extend() *Registry {
+	return &Registry{rules: make(map[string]RuleFunc), parent: r}
+}
+
+// Register adds the custom rule fn to the registry under the given name. A registered rule
+// is replaced. It returns an error if the name is not valid or it is the name of a builtin rule.
+func (r *Registry) Register(name string, fn RuleFunc) error {
+	if name == "" || strings.ContainsAny(name, ",= ") {
+		return errors.New("Invalid rule name `" + name + "`")
+	}
+	if _, ok := errRule(name); ok || builtinRules[name] {
+		return errors.New("Cannot register the builtin rule " + name)
+	}
+	if fn == nil {
+		return errors.New("Cannot register a nil function for the rule " + name)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rules[name] = fn
+	return nil
+}
+
+// Lookup returns the custom rule with the given name. If the rule is not defined by the Registry,
+// it is looked up in the Registry which is extended. A nil Registry contains no rules.
+func (r *Registry) Lookup(name string) (RuleFunc, bool) {
+	if r == nil {
+		return nil, false
+	}
+	r.mu.RLock()
+	fn, ok := r.rules[name]
+	r.mu.RUnlock()
+	if !ok {
+		return r.parent.Lookup(name)
+	}
+	return fn, ok
+}
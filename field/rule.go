@@ -0,0 +1,52 @@
+package field
+
+// Rule describes a validation rule of the annotation.
+// For example the rule max=23 from `validate:"required=true,min=10,max=23"`
+// is described as Rule{Name: "max", Param: "23"}
+type Rule struct {
+	// Name is the name of the rule e.g. "max"
+	Name string
+
+	// Param is the argument of the rule e.g. "23"
+	Param string
+}
+
+// Rules describes the validation rules of a field in the order of the annotation.
+type Rules []Rule
+
+// Lookup returns the Param of the rule with the given name. If the rule is not
+// defined it returns false.
+func (rs Rules) Lookup(name string) (string, bool) {
+	if i := rs.index(name); i < len(rs) {
+		return rs[i].Param, true
+	}
+	return "", false
+}
+
+// Get returns the Param of the rule with the given name, or an empty string if
+// the rule is not defined.
+func (rs Rules) Get(name string) string {
+	param, _ := rs.Lookup(name)
+	return param
+}
+
+// index returns the position of the rule with the given name. If the rule is not
+// defined it returns len(rs).
+func (rs Rules) index(name string) int {
+	for i, r := range rs {
+		if r.Name == name {
+			return i
+		}
+	}
+	return len(rs)
+}
+
+// set sets the Param of the rule with the given name. If the rule is not defined,
+// it is added to the end of the rules.
+func (rs Rules) set(name, param string) Rules {
+	if i := rs.index(name); i < len(rs) {
+		rs[i].Param = param
+		return rs
+	}
+	return append(rs, Rule{Name: name, Param: param})
+}
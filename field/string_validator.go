@@ -1,14 +1,28 @@
 package field
 
 import (
+	"encoding/base64"
+	"errors"
+	"net"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
 	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+	"golang.org/x/text/unicode/norm"
+	"golang.org/x/text/width"
 )
 
 // str struct describes the string validator. A string validator is
 // responsible to define the rules of validation and validate a string property.
 type str struct {
 	// valy.Field embedded to str validator to have access to Field's properties.
-	Field
+	*Field
 
 	// min defines the min character that can contains this field.
 	min int
@@ -16,56 +30,204 @@ type str struct {
 	// max defines the max character that can contains this field.
 	max int
 
+	// count defines how the characters of the min and max rules are counted, one of the stringCounts.
+	count string
+
+	// minBytes defines the min bytes that can contains this field.
+	minBytes int
+
+	// maxBytes defines the max bytes that can contains this field.
+	maxBytes int
+
+	// normalized defines the Unicode normalization form of the field.
+	normalized norm.Form
+
 	// require defines if the field has to be set.
 	required bool
 
+	// nonzero defines if the field has to be a non empty string, the same way as the required rule.
+	nonzero bool
+
+	// formats defines which of the stringFormats rules are enabled e.g. formats["email"] = true.
+	formats map[string]bool
+
+	// prefix defines the prefix of the field.
+	prefix string
+
+	// suffix defines the suffix of the field.
+	suffix string
+
+	// contains defines the substring that the field has to contain.
+	contains string
+
+	// oneof defines the values that the field can have.
+	oneof []string
+
+	// regex defines the regular expression that the field has to match.
+	regex *regexp.Regexp
+
+	// pattern defines the regular expression that the whole field has to match.
+	pattern *regexp.Regexp
+
 	// value is the value of the field.
 	value string
 }
 
+// stringCounts contains the ways of counting the characters of a string by the count rule, and the unit
+// of the count in the error messages.
+var stringCounts = map[string]string{
+	"runes":     "characters",
+	"graphemes": "characters",
+	"width":     "columns",
+	"bytes":     "bytes",
+}
+
+// normForms contains the Unicode normalization forms of the normalized rule.
+var normForms = map[string]norm.Form{
+	"NFC":  norm.NFC,
+	"NFD":  norm.NFD,
+	"NFKC": norm.NFKC,
+	"NFKD": norm.NFKD,
+}
+
+// stringFormat describes a rule which checks the format of a string e.g. `validate:"email=true"`.
+type stringFormat struct {
+	// valid reports whether the string has the format.
+	valid func(s string) bool
+
+	// message is the default error message after the field name.
+	message string
+}
+
+// stringFormats contains the format rules of the string validator by name.
+var stringFormats = map[string]stringFormat{
+	"email":        {isEmail, "should be a valid email address"},
+	"url":          {isURL, "should be a valid URL"},
+	"uuid":         {isUUID, "should be a valid UUID"},
+	"hostname":     {isHostname, "should be a valid hostname"},
+	"ip":           {isIP, "should be a valid IP address"},
+	"ipv4":         {isIPv4, "should be a valid IPv4 address"},
+	"ipv6":         {isIPv6, "should be a valid IPv6 address"},
+	"cidr":         {isCIDR, "should be a valid CIDR notation"},
+	"hex":          {isHex, "should contain only hexadecimal characters"},
+	"base64":       {isBase64, "should be base64 encoded"},
+	"alpha":        {isAlpha, "should contain only letters"},
+	"alphanumeric": {isAlphanumeric, "should contain only letters and numbers"},
+}
+
+// uuidRegex matches the UUIDs in the canonical form e.g. "123e4567-e89b-12d3-a456-426614174000".
+var uuidRegex = regexp.MustCompile(`^[0-9a-fA-F]{8}(-[0-9a-fA-F]{4}){3}-[0-9a-fA-F]{12}$`)
+
+// hostnameRegex matches a label of a hostname according to RFC 1123.
+var hostnameRegex = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?$`)
+
 // newString initializes and returns a str.
 func newString(fp *Field) *str {
 	nv := &str{
-		min:      -1,
-		max:      -1,
+		count:    "runes",
 		required: false,
 	}
-	nv.Field = *fp
+	nv.Field = fp
 	return nv
 }
 
+// with returns a copy of the validator, with the parsed rules, for the field fp.
+func (n *str) with(fp *Field) validator {
+	nv := *n
+	nv.Field = fp
+	return &nv
+}
+
 // validate is responsible to validate this field. After this call
 // the function will return the errors if the field is invalid.
-func (n *str) validate() ([]string, error) {
-	v := n.Field.Value.(string)
+func (n *str) validate() ([]Error, error) {
+	v := reflect.ValueOf(n.Field.Value).String()
 	n.value = v
-	err := n.setRules(n.Field.Rules)
-	if err != nil {
-		return nil, err
-	}
-	if n.min > -1 {
-		n.minRule()
-	}
-	if n.max > -1 {
-		n.maxRule()
-	}
-	if n.required {
-		n.requiredRule()
+	// Apply the rules in the order of the annotation.
+	for _, r := range n.Rules {
+		switch {
+		case r.Name == "min":
+			n.minRule()
+		case r.Name == "max":
+			n.maxRule()
+		case r.Name == "required" && n.required:
+			n.requiredRule()
+		case r.Name == "nonzero" && n.nonzero && n.value == "":
+			n.fail("nonzero", "the field "+n.FieldName+" should not be empty")
+		case r.Name == "minbytes":
+			n.minBytesRule()
+		case r.Name == "maxbytes":
+			n.maxBytesRule()
+		case r.Name == "normalized":
+			n.normalizedRule()
+		case n.formats[r.Name]:
+			n.formatRule(r.Name)
+		case r.Name == "prefix":
+			n.prefixRule()
+		case r.Name == "suffix":
+			n.suffixRule()
+		case r.Name == "contains":
+			n.containsRule()
+		case r.Name == "oneof":
+			n.oneofRule()
+		case r.Name == "regex":
+			n.regexRule()
+		case r.Name == "pattern":
+			n.patternRule()
+		}
 	}
 	return n.Errs, nil
 }
 
 // setRules sets the rules for the current field.
-func (n *str) setRules(rules map[string]string) error {
+func (n *str) setRules(rules Rules) error {
 	var err error
-	for k, v := range rules {
-		switch k {
+	for _, r := range rules {
+		switch r.Name {
 		case "min":
-			n.min, err = strconv.Atoi(v)
+			n.min, err = parseCount(r.Name, r.Param)
 		case "max":
-			n.max, err = strconv.Atoi(v)
+			n.max, err = parseCount(r.Name, r.Param)
 		case "required":
-			n.required, err = strconv.ParseBool(v)
+			n.required, err = strconv.ParseBool(r.Param)
+		case "nonzero":
+			n.nonzero, err = strconv.ParseBool(r.Param)
+		case "count":
+			if _, ok := stringCounts[r.Param]; !ok {
+				return errors.New("Invalid count `" + r.Param + "`, it should be runes, graphemes, width or bytes")
+			}
+			n.count = r.Param
+		case "minbytes":
+			n.minBytes, err = parseCount(r.Name, r.Param)
+		case "maxbytes":
+			n.maxBytes, err = parseCount(r.Name, r.Param)
+		case "normalized":
+			f, ok := normForms[strings.ToUpper(r.Param)]
+			if !ok {
+				return errors.New("Invalid normalization form `" + r.Param + "`, it should be NFC, NFD, NFKC or NFKD")
+			}
+			n.normalized = f
+		case "prefix":
+			n.prefix = r.Param
+		case "suffix":
+			n.suffix = r.Param
+		case "contains":
+			n.contains = r.Param
+		case "oneof":
+			n.oneof = strings.Fields(r.Param)
+		case "regex":
+			n.regex, err = regexp.Compile(r.Param)
+		case "pattern":
+			n.pattern, err = regexp.Compile(`^(?:` + r.Param + `)$`)
+		default:
+			if _, ok := stringFormats[r.Name]; !ok {
+				err = n.kindRuleError(r.Name)
+				break
+			}
+			if n.formats == nil {
+				n.formats = make(map[string]bool)
+			}
+			n.formats[r.Name], err = strconv.ParseBool(r.Param)
 		}
 		if err != nil {
 			return err
@@ -74,25 +236,257 @@ func (n *str) setRules(rules map[string]string) error {
 	return nil
 }
 
+// parseCount parses the count of the rule e.g. the characters of the min rule. The count can not be negative.
+func parseCount(rule, s string) (int, error) {
+	c, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, err
+	}
+	if c < 0 {
+		return 0, errors.New("Invalid count `" + s + "` of the rule " + rule + ", it should not be negative")
+	}
+	return c, nil
+}
+
 // minRule checks if field contains less than X characters.
 func (n *str) minRule() {
-	if len(n.value) < n.min {
-		n.Field.Errs = append(n.Field.Errs, "the field "+n.Field.FieldName+" should contains at least "+
-			strconv.Itoa(n.min)+" characters")
+	if n.length() < n.min {
+		n.fail("min", "the field "+n.FieldName+" should contains at least "+
+			strconv.Itoa(n.min)+" "+stringCounts[n.count])
 	}
 }
 
 // maxRule checks if field contains more than X characters.
 func (n *str) maxRule() {
-	if len(n.value) > n.max {
-		n.Field.Errs = append(n.Field.Errs, "the field "+n.Field.FieldName+" should contains max "+
-			strconv.Itoa(n.max)+" characters")
+	if n.length() > n.max {
+		n.fail("max", "the field "+n.FieldName+" should contains max "+
+			strconv.Itoa(n.max)+" "+stringCounts[n.count])
+	}
+}
+
+// minBytesRule checks if field contains less than X bytes.
+func (n *str) minBytesRule() {
+	if len(n.value) < n.minBytes {
+		n.fail("minbytes", "the field "+n.FieldName+" should contains at least "+
+			strconv.Itoa(n.minBytes)+" bytes")
+	}
+}
+
+// maxBytesRule checks if field contains more than X bytes.
+func (n *str) maxBytesRule() {
+	if len(n.value) > n.maxBytes {
+		n.fail("maxbytes", "the field "+n.FieldName+" should contains max "+
+			strconv.Itoa(n.maxBytes)+" bytes")
+	}
+}
+
+// normalizedRule checks if field is in the Unicode normalization form.
+func (n *str) normalizedRule() {
+	if !n.normalized.IsNormalString(n.value) {
+		n.fail("normalized", "the field "+n.FieldName+" should be in the Unicode normalization form "+
+			n.Rules.Get("normalized"))
 	}
 }
 
+// length returns the length of the field according to the count rule. By default it counts the runes,
+// so a character like "ω" counts once although it is encoded by two bytes.
+func (n *str) length() int {
+	switch n.count {
+	case "graphemes":
+		return graphemeCount(n.value)
+	case "width":
+		return displayWidth(n.value)
+	case "bytes":
+		return len(n.value)
+	}
+	return utf8.RuneCountInString(n.value)
+}
+
 // requiredRule check if field is defined.
 func (n *str) requiredRule() {
 	if n.value == "" {
-		n.Field.Errs = append(n.Field.Errs, "the field "+n.Field.FieldName+" should not be empty")
+		n.fail("required", "the field "+n.FieldName+" should not be empty")
+	}
+}
+
+// formatRule checks if field has the format of the rule name e.g. "email".
+func (n *str) formatRule(name string) {
+	if f := stringFormats[name]; !f.valid(n.value) {
+		n.fail(name, "the field "+n.FieldName+" "+f.message)
+	}
+}
+
+// prefixRule checks if field starts with the prefix.
+func (n *str) prefixRule() {
+	if !strings.HasPrefix(n.value, n.prefix) {
+		n.fail("prefix", "the field "+n.FieldName+" should start with "+n.prefix)
+	}
+}
+
+// suffixRule checks if field ends with the suffix.
+func (n *str) suffixRule() {
+	if !strings.HasSuffix(n.value, n.suffix) {
+		n.fail("suffix", "the field "+n.FieldName+" should end with "+n.suffix)
+	}
+}
+
+// containsRule checks if field contains the substring.
+func (n *str) containsRule() {
+	if !strings.Contains(n.value, n.contains) {
+		n.fail("contains", "the field "+n.FieldName+" should contain "+n.contains)
+	}
+}
+
+// oneofRule checks if field is one of the space separated values e.g. oneof='red green blue'.
+func (n *str) oneofRule() {
+	for _, v := range n.oneof {
+		if n.value == v {
+			return
+		}
+	}
+	n.fail("oneof", "the field "+n.FieldName+" should be one of "+strings.Join(n.oneof, ", "))
+}
+
+// regexRule checks if any part of the field matches the regular expression.
+func (n *str) regexRule() {
+	if !n.regex.MatchString(n.value) {
+		n.fail("regex", "the field "+n.FieldName+" should match the regular expression "+n.regex.String())
+	}
+}
+
+// patternRule checks if the whole field matches the regular expression.
+func (n *str) patternRule() {
+	if !n.pattern.MatchString(n.value) {
+		n.fail("pattern", "the field "+n.FieldName+" should match the pattern "+n.Rules.Get("pattern"))
+	}
+}
+
+// isEmail reports whether s is an email address without a display name e.g. "user@example.com".
+func isEmail(s string) bool {
+	a, err := mail.ParseAddress(s)
+	return err == nil && a.Address == s && a.Name == ""
+}
+
+// isURL reports whether s is an absolute URL with a scheme and a host e.g. "https://example.com/path".
+func isURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && u.Scheme != "" && u.Host != ""
+}
+
+// isUUID reports whether s is a UUID in the canonical form.
+func isUUID(s string) bool {
+	return uuidRegex.MatchString(s)
+}
+
+// isHostname reports whether s is a hostname according to RFC 1123 e.g. "api.example.com".
+func isHostname(s string) bool {
+	s = strings.TrimSuffix(s, ".")
+	if s == "" || len(s) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(s, ".") {
+		if !hostnameRegex.MatchString(label) {
+			return false
+		}
+	}
+	return true
+}
+
+// isIP reports whether s is an IPv4 or IPv6 address.
+func isIP(s string) bool {
+	return net.ParseIP(s) != nil
+}
+
+// isIPv4 reports whether s is an IPv4 address e.g. "192.168.1.1".
+func isIPv4(s string) bool {
+	return net.ParseIP(s) != nil && !strings.Contains(s, ":")
+}
+
+// isIPv6 reports whether s is an IPv6 address e.g. "2001:db8::1".
+func isIPv6(s string) bool {
+	return net.ParseIP(s) != nil && strings.Contains(s, ":")
+}
+
+// isCIDR reports whether s is an IP address and prefix length in CIDR notation e.g. "10.0.0.0/8".
+func isCIDR(s string) bool {
+	_, _, err := net.ParseCIDR(s)
+	return err == nil
+}
+
+// isHex reports whether s contains only hexadecimal digits.
+func isHex(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if !unicode.Is(unicode.ASCII_Hex_Digit, c) {
+			return false
+		}
+	}
+	return true
+}
+
+// isBase64 reports whether s is encoded with the standard base64 encoding.
+func isBase64(s string) bool {
+	_, err := base64.StdEncoding.DecodeString(s)
+	return s != "" && err == nil
+}
+
+// isAlpha reports whether s contains only letters of any language.
+func isAlpha(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if !unicode.IsLetter(c) {
+			return false
+		}
+	}
+	return true
+}
+
+// isAlphanumeric reports whether s contains only letters and digits of any language.
+func isAlphanumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) {
+			return false
+		}
+	}
+	return true
+}
+
+// graphemeCount returns the number of the user-perceived characters of s. It follows the extended grapheme
+// cluster rules of UAX #29 e.g. "e\u0301", "👍🏽", "👩‍💻", "🇬🇷", "\r\n" and the decomposed Hangul syllables
+// count once.
+func graphemeCount(s string) int {
+	return uniseg.GraphemeClusterCount(s)
+}
+
+// isExtend reports whether the rune r extends the previous grapheme cluster.
+func isExtend(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc) ||
+		r == '\u200d' ||
+		(r >= 0xFE00 && r <= 0xFE0F) || (r >= 0xE0100 && r <= 0xE01EF) ||
+		(r >= 0x1F3FB && r <= 0x1F3FF) ||
+		(r >= 0xE0020 && r <= 0xE007F)
+}
+
+// displayWidth returns the number of the columns s occupies in a monospace font. The wide and fullwidth
+// East Asian characters and the emoji occupy two columns and the combining marks and the zero width runes
+// do not occupy any column.
+func displayWidth(s string) int {
+	w := 0
+	for _, r := range s {
+		switch {
+		case isExtend(r) || unicode.Is(unicode.Cf, r):
+		case width.LookupRune(r).Kind() == width.EastAsianWide, width.LookupRune(r).Kind() == width.EastAsianFullwidth:
+			w += 2
+		default:
+			w++
+		}
 	}
+	return w
 }
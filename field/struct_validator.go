@@ -0,0 +1,71 @@
+package field
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// structure struct describes the validator for struct values, e.g. a nested struct field. A structure
+// validator is responsible to define the rules of the struct itself, the fields of the struct are
+// validated by their own rules.
+type structure struct {
+	// valy.Field embedded to structure validator to have access to Field's properties.
+	*Field
+
+	// require defines if the field has to be provided, so it is not nil and it is not the zero struct.
+	required bool
+
+	// nonzero defines if the field has to be a non zero struct, the same way as the required rule.
+	nonzero bool
+}
+
+// newStructure initializes and returns a structure.
+func newStructure(fp *Field) *structure {
+	nv := &structure{
+		required: false,
+		nonzero:  false,
+	}
+	nv.Field = fp
+	return nv
+}
+
+// with returns a copy of the validator, with the parsed rules, for the field fp.
+func (n *structure) with(fp *Field) validator {
+	nv := *n
+	nv.Field = fp
+	return &nv
+}
+
+// validate is responsible to validate this field. After this call
+// the function will return the errors if the field is invalid.
+func (n *structure) validate() ([]Error, error) {
+	// Apply the rules in the order of the annotation.
+	for _, r := range n.Rules {
+		switch {
+		case r.Name == "required" && n.required && !n.isPresent():
+			n.fail("required", "the field "+n.FieldName+" should not be empty")
+		case r.Name == "nonzero" && n.nonzero && reflect.ValueOf(n.Value).IsZero():
+			n.fail("nonzero", "the field "+n.FieldName+" should not be zero")
+		}
+	}
+	return n.Errs, nil
+}
+
+// setRules sets the rules for the current field.
+func (n *structure) setRules(rules Rules) error {
+	var err error
+	for _, r := range rules {
+		switch r.Name {
+		case "required":
+			n.required, err = strconv.ParseBool(r.Param)
+		case "nonzero":
+			n.nonzero, err = strconv.ParseBool(r.Param)
+		default:
+			err = n.kindRuleError(r.Name)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
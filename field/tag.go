@@ -0,0 +1,175 @@
+package field
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// SyntaxError describes an error of the annotation's syntax. It contains the column of the annotation
+// where the error was found.
+type SyntaxError struct {
+	// Column is the position of the error in the annotation, starting from 1.
+	Column int
+
+	// Msg describes the error.
+	Msg string
+}
+
+// Error returns the description of the error and its column.
+func (e *SyntaxError) Error() string {
+	return "syntax error at column " + strconv.Itoa(e.Column) + ": " + e.Msg
+}
+
+// ParseTag parses the validation annotation of a field and returns its validations in the order of the
+// annotation. The validations are the rules in the form name=param and the bare flags e.g. "required"
+// or "dive". The flags are applied by the validators as name=true.
+//
+// The rules are separated by commas and the spaces around them are ignored. The param of a rule can be
+// quoted by single quotes, so it can contain commas, equal signs and spaces e.g.
+//
+// `validate:"required,oneof='red green blue',Err='Hello, world'"`
+//
+// The backslash escapes a quote, a comma or a backslash e.g. 'it\'s' or Hello\, world. Any other backslash
+// is kept as it is, so the regular expressions do not have to be escaped e.g. regex=^\d+$.
+// If the annotation is not valid it returns a *SyntaxError.
+func ParseTag(tag string) ([]string, error) {
+	if strings.TrimSpace(tag) == "" {
+		return nil, nil
+	}
+	p := &tagParser{tag: tag}
+	var validations []string
+	for {
+		v, err := p.parseRule()
+		if err != nil {
+			return nil, err
+		}
+		validations = append(validations, v)
+		if p.eof() {
+			return validations, nil
+		}
+		// parseRule stops only at the end of the annotation or at a comma.
+		p.next()
+	}
+}
+
+// tagParser describes the state of the parsing of an annotation.
+type tagParser struct {
+	// tag is the annotation.
+	tag string
+
+	// pos is the byte offset of the next rune.
+	pos int
+}
+
+// eof reports whether the whole annotation is parsed.
+func (p *tagParser) eof() bool {
+	return p.pos >= len(p.tag)
+}
+
+// peek returns the next rune without consuming it.
+func (p *tagParser) peek() rune {
+	r, _ := utf8.DecodeRuneInString(p.tag[p.pos:])
+	return r
+}
+
+// next consumes and returns the next rune.
+func (p *tagParser) next() rune {
+	r, size := utf8.DecodeRuneInString(p.tag[p.pos:])
+	p.pos += size
+	return r
+}
+
+// column returns the column of the next rune, starting from 1.
+func (p *tagParser) column() int {
+	return utf8.RuneCountInString(p.tag[:p.pos]) + 1
+}
+
+// error returns a *SyntaxError at the column of the next rune.
+func (p *tagParser) error(msg string) error {
+	return &SyntaxError{Column: p.column(), Msg: msg}
+}
+
+// skipSpaces consumes the spaces.
+func (p *tagParser) skipSpaces() {
+	for !p.eof() && p.peek() == ' ' {
+		p.next()
+	}
+}
+
+// parseRule parses a rule until the next comma or the end of the annotation.
+func (p *tagParser) parseRule() (string, error) {
+	p.skipSpaces()
+	start := p.pos
+	for !p.eof() && p.peek() != ',' && p.peek() != '=' {
+		if c := p.peek(); c == '\'' || c == '\\' {
+			return "", p.error("unexpected " + strconv.QuoteRune(c) + " in the rule name")
+		}
+		p.next()
+	}
+	name := strings.TrimSpace(p.tag[start:p.pos])
+	if name == "" {
+		if p.eof() || p.peek() == ',' {
+			return "", p.error("empty rule")
+		}
+		return "", p.error("missing rule name before '='")
+	}
+	if strings.ContainsRune(name, ' ') {
+		return "", &SyntaxError{Column: utf8.RuneCountInString(p.tag[:start]) + 1,
+			Msg: "unexpected space in the rule name " + strconv.Quote(name)}
+	}
+	if p.eof() || p.peek() == ',' {
+		return name, nil
+	}
+	// Consume the equal sign.
+	p.next()
+	p.skipSpaces()
+	if !p.eof() && p.peek() == '\'' {
+		param, err := p.parseQuoted()
+		if err != nil {
+			return "", err
+		}
+		p.skipSpaces()
+		if !p.eof() && p.peek() != ',' {
+			return "", p.error("unexpected " + strconv.QuoteRune(p.peek()) + " after the quoted param of the rule " + name)
+		}
+		return name + "=" + param, nil
+	}
+	var b strings.Builder
+	for !p.eof() && p.peek() != ',' {
+		c := p.next()
+		if c == '\'' {
+			return "", &SyntaxError{Column: p.column() - 1,
+				Msg: "unexpected quote in the param of the rule " + name + ", quote the whole param"}
+		}
+		if c == '\\' && !p.eof() && isEscaped(p.peek()) {
+			c = p.next()
+		}
+		b.WriteRune(c)
+	}
+	return name + "=" + strings.TrimRight(b.String(), " "), nil
+}
+
+// parseQuoted parses a param quoted by single quotes and returns it without the quotes.
+func (p *tagParser) parseQuoted() (string, error) {
+	column := p.column()
+	// Consume the opening quote.
+	p.next()
+	var b strings.Builder
+	for !p.eof() {
+		c := p.next()
+		switch {
+		case c == '\'':
+			return b.String(), nil
+		case c == '\\' && !p.eof() && isEscaped(p.peek()):
+			c = p.next()
+		}
+		b.WriteRune(c)
+	}
+	return "", &SyntaxError{Column: column, Msg: "unterminated quoted param"}
+}
+
+// isEscaped reports whether the rune c can be escaped by a backslash.
+func isEscaped(c rune) bool {
+	return c == '\'' || c == ',' || c == '\\'
+}
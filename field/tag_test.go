@@ -0,0 +1,76 @@
+package field_test
+
+import (
+	"errors"
+	"github.com/cpapidas/valy/field"
+	"reflect"
+	"testing"
+)
+
+func TestParseTag_shouldParseTheRules(t *testing.T) {
+	tests := []struct {
+		tag      string
+		expected []string
+	}{
+		{"", nil},
+		{"required=true,min=10,max=23", []string{"required=true", "min=10", "max=23"}},
+		{"required, min = 10 ,max=23", []string{"required", "min=10", "max=23"}},
+		{"max=3,dive,keys,min=2,endkeys,min=1", []string{"max=3", "dive", "keys", "min=2", "endkeys", "min=1"}},
+		{"oneof='red green blue'", []string{"oneof=red green blue"}},
+		{"Err='Hello, world = 1'", []string{"Err=Hello, world = 1"}},
+		{"Err=Hello\\, world", []string{"Err=Hello, world"}},
+		{"Err='it\\'s \\\\ fine'", []string{"Err=it's \\ fine"}},
+		{"regex=^\\d+,[a-z]=$", []string{"regex=^\\d+", "[a-z]=$"}},
+		{"regex='^\\d{1,3}$'", []string{"regex=^\\d{1,3}$"}},
+		{"Err=", []string{"Err="}},
+		{"Err=''", []string{"Err="}},
+	}
+	for _, tt := range tests {
+		validations, err := field.ParseTag(tt.tag)
+		if err != nil {
+			t.Fatalf("%s: expected not return an error but got: %v", tt.tag, err)
+		}
+		if !reflect.DeepEqual(validations, tt.expected) {
+			t.Errorf("%s: expected %q, but got %q", tt.tag, tt.expected, validations)
+		}
+	}
+}
+
+func TestParseTag_shouldReturnTheColumnOfSyntaxErrors(t *testing.T) {
+	tests := []struct {
+		tag     string
+		column  int
+		message string
+	}{
+		{"required,,min=1", 10, "empty rule"},
+		{"required,", 10, "empty rule"},
+		{"min=1,=3", 7, "missing rule name before '='"},
+		{"oneof='a b", 7, "unterminated quoted param"},
+		{"oneof='a b'c", 12, "unexpected 'c' after the quoted param of the rule oneof"},
+		{"Err=it's", 7, "unexpected quote in the param of the rule Err, quote the whole param"},
+		{"min 3=1", 1, "unexpected space in the rule name \"min 3\""},
+		{"ώρα,'max=1", 5, "unexpected '\\'' in the rule name"},
+	}
+	for _, tt := range tests {
+		_, err := field.ParseTag(tt.tag)
+		var syntaxErr *field.SyntaxError
+		if !errors.As(err, &syntaxErr) {
+			t.Fatalf("%s: expected a syntax error but got: %v", tt.tag, err)
+		}
+		if syntaxErr.Column != tt.column || syntaxErr.Msg != tt.message {
+			t.Errorf("%s: expected the error %q at column %d, but got: %v", tt.tag, tt.message, tt.column, err)
+		}
+	}
+}
+
+func TestField_CallValidator_shouldApplyTheFlags(t *testing.T) {
+	f := field.Field{Kind: "string", Value: "", FieldName: "Color"}
+	valsErrs, err := f.CallValidator([]string{"required", "oneof=red green blue"})
+	if err != nil {
+		t.Fatalf("expected not return an error but got: %v", err)
+	}
+	expected := []string{"the field Color should not be empty", "the field Color should be one of red, green, blue"}
+	if !reflect.DeepEqual(valsErrs, expected) {
+		t.Errorf("should return the errors: %v, but got %v", expected, valsErrs)
+	}
+}
@@ -0,0 +1,209 @@
+package field
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// now returns the current time, which the relative bounds of the time rules are relative to.
+var now = time.Now
+
+// timeLayouts contains the layouts of the absolute bounds of the time rules. The bounds without a time zone are in UTC.
+var timeLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02"}
+
+// timeValidator struct describes the validator for time.Time values. A time validator is
+// responsible to define the rules of validation and validate a time.Time property.
+//
+// For example, if you have the following struct:
+//
+// struct Person {
+//   BirthDate time.Time `validate:"required,before=now-18y,after=1900-01-01"`
+//   Holiday   time.Time `validate:"between='2024-07-01 2024-08-31'"`
+// }
+//
+// The BirthDate should be more than 18 years before the time of the validation and the Holiday
+// should be in July or August of 2024. The before and after bounds are exclusive and the bounds of the
+// between rule are inclusive.
+type timeValidator struct {
+	// valy.Field embedded to time validator to have access to Field's properties.
+	*Field
+
+	// before defines the time the field has to be before of.
+	before *timeBound
+
+	// after defines the time the field has to be after of.
+	after *timeBound
+
+	// between defines the times the field has to be between of.
+	between []timeBound
+
+	// require defines if the field has to be set.
+	required bool
+
+	// nonzero defines if the field has to be a non zero time.
+	nonzero bool
+
+	// value is the value of the field.
+	value time.Time
+}
+
+// newTime initializes and returns a timeValidator.
+func newTime(fp *Field) *timeValidator {
+	nv := &timeValidator{
+		required: false,
+	}
+	nv.Field = fp
+	return nv
+}
+
+// with returns a copy of the validator, with the parsed rules, for the field fp.
+func (n *timeValidator) with(fp *Field) validator {
+	nv := *n
+	nv.Field = fp
+	return &nv
+}
+
+// validate is responsible to validate this field. After this call
+// the function will return the errors if the field is invalid.
+func (n *timeValidator) validate() ([]Error, error) {
+	n.value = n.Value.(time.Time)
+	t := now()
+	// Apply the rules in the order of the annotation.
+	for _, r := range n.Rules {
+		switch {
+		case r.Name == "before" && n.before != nil && !n.value.Before(n.before.at(t)):
+			n.fail("before", "the field "+n.FieldName+" should be before "+r.Param)
+		case r.Name == "after" && n.after != nil && !n.value.After(n.after.at(t)):
+			n.fail("after", "the field "+n.FieldName+" should be after "+r.Param)
+		case r.Name == "between" && n.between != nil &&
+			(n.value.Before(n.between[0].at(t)) || n.value.After(n.between[1].at(t))):
+			n.fail("between", "the field "+n.FieldName+" should be between "+n.between[0].s+" and "+n.between[1].s)
+		case r.Name == "required" && n.required && n.value.IsZero():
+			n.fail("required", "the field "+n.FieldName+" should not be empty")
+		case r.Name == "nonzero" && n.nonzero && n.value.IsZero():
+			n.fail("nonzero", "the field "+n.FieldName+" should not be zero")
+		}
+	}
+	return n.Errs, nil
+}
+
+// setRules sets the rules for the current field.
+func (n *timeValidator) setRules(rules Rules) error {
+	var err error
+	for _, r := range rules {
+		switch r.Name {
+		case "before":
+			n.before = new(timeBound)
+			*n.before, err = parseTimeBound(r.Param)
+		case "after":
+			n.after = new(timeBound)
+			*n.after, err = parseTimeBound(r.Param)
+		case "between":
+			params := strings.Fields(r.Param)
+			if len(params) != 2 {
+				return errors.New("the rule between should define two times e.g. between='2024-01-01 now'")
+			}
+			n.between = make([]timeBound, 2)
+			if n.between[0], err = parseTimeBound(params[0]); err == nil {
+				n.between[1], err = parseTimeBound(params[1])
+			}
+		case "required":
+			n.required, err = strconv.ParseBool(r.Param)
+		case "nonzero":
+			n.nonzero, err = strconv.ParseBool(r.Param)
+		default:
+			err = n.kindRuleError(r.Name)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// timeBound describes a bound of the time rules. It is an absolute time e.g. "2000-01-01" or "2024-05-01T10:00:00Z",
+// or a time relative to the time of the validation e.g. "now", "now-18y" or "now+1mo15d". The units of the
+// relative bounds are y (years), mo (months), w (weeks), d (days) and the units of time.ParseDuration e.g. h or m.
+type timeBound struct {
+	// s is the bound as it is defined in the annotation.
+	s string
+
+	// abs is the absolute time of the bound. It is the zero time for the relative bounds.
+	abs time.Time
+
+	// relative defines if the bound is relative to the time of the validation.
+	relative bool
+
+	// years, months and days are added to the time of the validation by time.AddDate.
+	years, months, days int
+
+	// offset is added to the time of the validation after the years, months and days.
+	offset time.Duration
+}
+
+// at returns the time of the bound, the relative bounds are relative to the time t.
+func (b timeBound) at(t time.Time) time.Time {
+	if !b.relative {
+		return b.abs
+	}
+	return t.AddDate(b.years, b.months, b.days).Add(b.offset)
+}
+
+// parseTimeBound parses a bound of the time rules, see timeBound.
+func parseTimeBound(s string) (timeBound, error) {
+	b := timeBound{s: s}
+	if !strings.HasPrefix(s, "now") {
+		for _, layout := range timeLayouts {
+			if t, err := time.Parse(layout, s); err == nil {
+				b.abs = t
+				return b, nil
+			}
+		}
+		return b, errors.New("Invalid time `" + s + "`, it should be e.g. 2006-01-02, 2006-01-02T15:04:05Z07:00 or now-18y")
+	}
+	b.relative = true
+	invalid := errors.New("Invalid time `" + s + "`, the offsets should be e.g. now-18y, now+1mo15d or now-2h30m")
+	sign, rest := 0, s[3:]
+	for rest != "" {
+		// Each offset is a number and a unit e.g. "18y", after a sign which applies to the next offsets too.
+		if rest[0] == '+' || rest[0] == '-' {
+			sign = 1
+			if rest[0] == '-' {
+				sign = -1
+			}
+			rest = rest[1:]
+		}
+		num := rest[:len(rest)-len(strings.TrimLeft(rest, "0123456789."))]
+		rest = rest[len(num):]
+		unit := rest[:len(rest)-len(strings.TrimLeft(rest, "abcdefghijklmnopqrstuvwxyzµ"))]
+		rest = rest[len(unit):]
+		if sign == 0 || num == "" || unit == "" {
+			return b, invalid
+		}
+		if unit == "y" || unit == "mo" || unit == "w" || unit == "d" {
+			n, err := strconv.Atoi(num)
+			if err != nil {
+				return b, errors.New("Invalid time `" + s + "`, the " + unit + " offset should be an integer")
+			}
+			switch unit {
+			case "y":
+				b.years += sign * n
+			case "mo":
+				b.months += sign * n
+			case "w":
+				b.days += sign * 7 * n
+			case "d":
+				b.days += sign * n
+			}
+			continue
+		}
+		d, err := time.ParseDuration(num + unit)
+		if err != nil {
+			return b, errors.New("Invalid time `" + s + "`, the unit " + unit + " should be y, mo, w, d, h, m, s, ms, us or ns")
+		}
+		b.offset += time.Duration(sign) * d
+	}
+	return b, nil
+}
@@ -0,0 +1,158 @@
+package valy
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// structField describes a struct field which is going to be validated.
+//
+// For example, if you have the following structs:
+//
+// type Base struct {
+//   ID int `validate:"required=true"`
+// }
+// type User struct {
+//   Base
+//   Username string `json:"username" validate:"required=true,min=10,max=23"`
+// }
+//
+// The User struct has the fields:
+//
+// {name: "ID", index: [0, 0], tag: "required=true"}
+// {name: "Username", index: [1], tag: "required=true,min=10,max=23"}
+//
+// and if the keys of the errors are defined by the json tag:
+//
+// {name: "ID", index: [0, 0], tag: "required=true"}
+// {name: "username", index: [1], tag: "required=true,min=10,max=23", tagged: true}
+type structField struct {
+	// name is the key of the field in the errors.
+	name string
+
+	// index is the index sequence of the field, for the promoted fields it contains
+	// the index of each embedded struct as well.
+	index []int
+
+	// tag is the validation annotation of the field.
+	tag string
+
+	// typ is the type of the field.
+	typ reflect.Type
+
+	// tagged reports whether the name of the field is defined by the key tag.
+	tagged bool
+}
+
+// structFields returns the fields of the struct type t which are going to be validated. The validation
+// annotation of each field is defined by the tag tagName e.g. "validate". The name of
+// each field is defined by the tag keyTag e.g. "json", or by the name of the struct field if keyTag is
+// empty or the field has not a name in the tag.
+//
+// The fields of the embedded structs are promoted to t following the encoding/json rules. A promoted field
+// is hidden by any field with the same name at a shallower depth, and the fields with the same name at the
+// same depth hide each other, unless only one of them is named by the key tag. The embedded struct pointers
+// are promoted as well, but an embedded struct named by the key tag is a nested struct. The unexported fields,
+// the fields annotated with "-", e.g. `validate:"-"`, and the fields with the key tag "-" are ignored.
+//
+// The fields are returned in declaration order.
+func structFields(t reflect.Type, tagName, keyTag string) []structField {
+	var fields []structField
+	// hidden contains the names of the fields found in the shallower depths.
+	hidden := make(map[string]bool)
+	visited := make(map[reflect.Type]bool)
+	next := []structField{{typ: t}}
+	for len(next) > 0 {
+		current := next
+		next = nil
+		var level []structField
+		count := make(map[string]int)
+		tagged := make(map[string]int)
+		for _, f := range current {
+			if visited[f.typ] {
+				continue
+			}
+			visited[f.typ] = true
+			for i := 0; i < f.typ.NumField(); i++ {
+				sf := f.typ.Field(i)
+				tag := sf.Tag.Get(tagName)
+				if tag == "-" {
+					continue
+				}
+				ft := sf.Type
+				if ft.Kind() == reflect.Ptr {
+					ft = ft.Elem()
+				}
+				if sf.PkgPath != "" && !(sf.Anonymous && ft.Kind() == reflect.Struct) {
+					continue
+				}
+				name, isTagged, ok := keyName(sf, keyTag)
+				if !ok {
+					continue
+				}
+				index := make([]int, len(f.index)+1)
+				copy(index, f.index)
+				index[len(f.index)] = i
+				if sf.Anonymous && ft.Kind() == reflect.Struct && !isTagged {
+					next = append(next, structField{index: index, typ: ft})
+					continue
+				}
+				level = append(level, structField{name: name, index: index, tag: tag, typ: sf.Type, tagged: isTagged})
+				count[name]++
+				if isTagged {
+					tagged[name]++
+				}
+			}
+		}
+		for _, f := range level {
+			if hidden[f.name] {
+				continue
+			}
+			if count[f.name] > 1 && (tagged[f.name] != 1 || !f.tagged) {
+				continue
+			}
+			fields = append(fields, f)
+		}
+		for name := range count {
+			hidden[name] = true
+		}
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return lessIndex(fields[i].index, fields[j].index)
+	})
+	return fields
+}
+
+// keyName returns the name of the struct field sf according to the tag keyTag. The name is the first
+// part of the tag, so the options like omitempty are ignored. It returns false if the field is ignored
+// by the tag "-" and tagged is true if the name is defined by the tag.
+func keyName(sf reflect.StructField, keyTag string) (name string, tagged bool, ok bool) {
+	if keyTag == "" {
+		return sf.Name, false, true
+	}
+	tag := sf.Tag.Get(keyTag)
+	if tag == "-" {
+		return "", false, false
+	}
+	if i := strings.Index(tag, ","); i > -1 {
+		tag = tag[:i]
+	}
+	if tag == "" {
+		return sf.Name, false, true
+	}
+	return tag, true, true
+}
+
+// lessIndex reports whether the index sequence a comes before b in declaration order.
+func lessIndex(a, b []int) bool {
+	for k := range a {
+		if k >= len(b) {
+			return false
+		}
+		if a[k] != b[k] {
+			return a[k] < b[k]
+		}
+	}
+	return len(a) < len(b)
+}
@@ -1,3 +1,9 @@
 module github.com/cpapidas/valy
 
-go 1.14
+go 1.18
+
+require (
+	github.com/rivo/uniseg v0.4.7
+	golang.org/x/text v0.14.0
+	gopkg.in/yaml.v3 v3.0.1
+)
@@ -0,0 +1,138 @@
+package valy
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// StructKey is the key of the errors of the validated struct itself, which are added by its StructValidator
+// or returned by its Validatable. The errors of a nested struct itself are keyed by the path of the struct
+// e.g. "Room", so they follow the KeyTag option.
+const StructKey = "_struct"
+
+// StructValidator describes a struct which validates the invariants of its fields, which can not be
+// defined by the annotations. The ValidateStruct function is called after the rules of the annotations
+// and the errors of the report are merged with the errors of the annotations.
+//
+// HOW TO USE IT
+// func (b booking) ValidateStruct(r *valy.Report) {
+// 	if b.Adults+b.Children > b.Room.Capacity {
+// 		r.Add("Children", "the room can not host all the guests")
+// 	}
+// }
+type StructValidator interface {
+	ValidateStruct(r *Report)
+}
+
+// Validatable describes a struct which validates itself by a ValidateSelf function. The ValidateSelf function
+// is called after the rules of the annotations. If it returns ValidationErrors or a FieldError, their fields
+// are relative to the struct and they are merged with the errors of the annotations, otherwise the message
+// of the error is the error of the struct.
+//
+// The hook is not named Validate on purpose: a Validate() error function is usually a wrapper of
+// valy.Validate, so calling it from the validation would recurse forever. Such a function is never called
+// by valy.
+//
+// HOW TO USE IT
+// func (g guest) ValidateSelf() error {
+// 	if g.Age < 18 && g.Guardian == "" {
+// 		return errors.New("a minor guest should have a guardian")
+// 	}
+// 	return nil
+// }
+type Validatable interface {
+	ValidateSelf() error
+}
+
+// Report collects the errors of a StructValidator. The names of the fields are relative to the struct,
+// so the errors of a nested struct are keyed by its path e.g. "Address.PostCode".
+type Report struct {
+	// path is the path of the struct e.g. "Address.", or empty for the validated struct.
+	path string
+
+	// name is the key of the errors of the struct itself.
+	name string
+
+	// errs contains the errors of the report.
+	errs ValidationErrors
+
+	// ctx is the context of the validation.
+	ctx context.Context
+}
+
+// Context returns the context of the validation, see ValidateCtx.
+func (r *Report) Context() context.Context {
+	return r.ctx
+}
+
+// Add adds an error of the field of the struct, or of the struct itself if the field is empty.
+// The rule of the error is "struct".
+func (r *Report) Add(field, message string) {
+	r.AddError(FieldError{FieldName: field, Rule: "struct", Message: message})
+}
+
+// AddError adds the error e of the field e.FieldName of the struct, or of the struct itself if
+// the FieldName is empty.
+func (r *Report) AddError(e FieldError) {
+	if e.FieldName == "" {
+		e.FieldName = r.name
+	} else {
+		e.FieldName = r.path + e.FieldName
+	}
+	r.errs = append(r.errs, e)
+}
+
+// Errors returns the errors of the report.
+func (r *Report) Errors() ValidationErrors {
+	return r.errs
+}
+
+// structHooks calls the ValidateStruct or the ValidateSelf function of the struct v, if it implements
+// StructValidator or Validatable, and returns the errors of the struct. The path is the path of the struct.
+// If the struct implements both interfaces, only the ValidateStruct function is called.
+// The hooks of an unexported embedded struct which is named by the key tag are not called, because reflect
+// can not return its value.
+func structHooks(ctx context.Context, v reflect.Value, path string) ValidationErrors {
+	if !v.CanInterface() {
+		return nil
+	}
+	data := v.Interface()
+	if v.CanAddr() {
+		data = v.Addr().Interface()
+	} else if !implementsHooks(v.Type()) {
+		// The hooks have pointer receivers, so they are called on an addressable copy of the struct.
+		c := reflect.New(v.Type())
+		c.Elem().Set(v)
+		data = c.Interface()
+	}
+	name := strings.TrimSuffix(path, ".")
+	if name == "" {
+		name = StructKey
+	}
+	r := &Report{path: path, name: name, ctx: ctx}
+	switch s := data.(type) {
+	case StructValidator:
+		s.ValidateStruct(r)
+	case Validatable:
+		err := s.ValidateSelf()
+		var validationErrs ValidationErrors
+		var fieldErr FieldError
+		var fieldErrPtr *FieldError
+		switch {
+		case err == nil:
+		case errors.As(err, &validationErrs):
+			for _, e := range validationErrs {
+				r.AddError(e)
+			}
+		case errors.As(err, &fieldErrPtr):
+			r.AddError(*fieldErrPtr)
+		case errors.As(err, &fieldErr):
+			r.AddError(fieldErr)
+		default:
+			r.Add("", err.Error())
+		}
+	}
+	return r.errs
+}
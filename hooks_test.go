@@ -0,0 +1,186 @@
+package valy_test
+
+import (
+	"errors"
+	"github.com/cpapidas/valy"
+	"reflect"
+	"testing"
+)
+
+type demoRoom struct {
+	Capacity int `validate:"min=1"`
+}
+
+func (r *demoRoom) ValidateStruct(report *valy.Report) {
+	if r.Capacity > 4 {
+		report.Add("Capacity", "the room can host max 4 guests")
+	}
+}
+
+type demoGuest struct {
+	Name string `validate:"required"`
+	Age  int
+}
+
+func (g demoGuest) ValidateSelf() error {
+	if g.Age < 18 && g.Name == "" {
+		return errors.New("a guest should have a name or be an adult")
+	}
+	if g.Age > 120 {
+		return valy.FieldError{FieldName: "Age", Rule: "age", Param: "120", Value: g.Age, Message: "invalid age"}
+	}
+	return nil
+}
+
+type demoStay struct {
+	Adults   int `validate:"min=1"`
+	Children int
+	Room     demoRoom
+	Guests   []demoGuest
+	Extra    *demoRoom
+}
+
+func (s demoStay) ValidateStruct(report *valy.Report) {
+	if s.Adults+s.Children > s.Room.Capacity {
+		report.Add("Children", "the room can not host all the guests")
+	}
+	if s.Children > 0 && s.Adults == 0 {
+		report.Add("", "the children should be accompanied by an adult")
+	}
+}
+
+func TestValidate_shouldCallTheStructHooks(t *testing.T) {
+	d := demoStay{
+		Children: 2,
+		Room:     demoRoom{Capacity: 5},
+		Guests:   []demoGuest{{Name: "cpapidas", Age: 30}, {Age: 10}, {Name: "old", Age: 130}},
+	}
+	errs, err := valy.Errors(d)
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	expected := valy.ValidationErrors{
+		{FieldName: "Adults", Rule: "min", Param: "1", Value: 0, Message: "the field Adults should be grater than 1"},
+		{FieldName: "Room.Capacity", Rule: "struct", Message: "the room can host max 4 guests"},
+		{FieldName: "Guests[1].Name", Rule: "required", Param: "true", Value: "", Message: "the field Guests[1].Name should not be empty"},
+		{FieldName: "Guests[1]", Rule: "struct", Message: "a guest should have a name or be an adult"},
+		{FieldName: "Guests[2].Age", Rule: "age", Param: "120", Value: 130, Message: "invalid age"},
+		{FieldName: valy.StructKey, Rule: "struct", Message: "the children should be accompanied by an adult"},
+	}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("expected %v, but got: %v", expected, errs)
+	}
+}
+
+func TestValidate_shouldStopOnTheFirstErrorOfTheStructHooks(t *testing.T) {
+	vl := valy.New(valy.StopOnFirstError())
+	errs, err := vl.Errors(&demoStay{Adults: 1, Room: demoRoom{Capacity: 0}})
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	if len(errs) != 1 || errs[0].FieldName != "Room.Capacity" {
+		t.Errorf("expected only the first error, but got: %v", errs)
+	}
+	errs, err = vl.Errors(&demoStay{Adults: 3, Room: demoRoom{Capacity: 2}})
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	if len(errs) != 1 || errs[0].Message != "the room can not host all the guests" {
+		t.Errorf("expected the error of the struct hook, but got: %v", errs)
+	}
+}
+
+type demoHiddenHook struct {
+	Level int
+}
+
+func (h demoHiddenHook) ValidateSelf() error {
+	return errors.New("the hook should not be called")
+}
+
+type demoHiddenHookHolder struct {
+	demoHiddenHook `json:"hidden"`
+}
+
+// ValidateSelf hides the hook of the embedded struct.
+func (h demoHiddenHookHolder) ValidateSelf() error {
+	return nil
+}
+
+func TestValidate_shouldSkipTheHooksOfUnexportedEmbeddedStructs(t *testing.T) {
+	errs, err := valy.ValidateWith(demoHiddenHookHolder{}, valy.KeyTag("json"))
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	if len(errs) != 0 {
+		t.Errorf("expected no errors, but got: %v", errs)
+	}
+}
+
+type demoJsonRoom struct {
+	Capacity int `json:"capacity"`
+}
+
+func (r demoJsonRoom) ValidateSelf() error {
+	if r.Capacity > 4 {
+		return errors.New("the room can host max 4 guests")
+	}
+	return nil
+}
+
+type demoJsonStay struct {
+	Adults   int          `json:"adults" validate:"min=1"`
+	Children int          `json:"children"`
+	Room     demoJsonRoom `json:"room"`
+}
+
+func (s demoJsonStay) ValidateStruct(report *valy.Report) {
+	if s.Children > 0 && s.Adults == 0 {
+		report.Add("", "the children should be accompanied by an adult")
+	}
+}
+
+func TestValidateWith_shouldKeyTheStructErrorsByTheKeyTag(t *testing.T) {
+	errs, err := valy.ValidateWith(demoJsonStay{Children: 1, Room: demoJsonRoom{Capacity: 5}}, valy.KeyTag("json"))
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	expected := map[string][]string{
+		"adults":       {"the field adults should be grater than 1"},
+		"room":         {"the room can host max 4 guests"},
+		valy.StructKey: {"the children should be accompanied by an adult"},
+	}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("expected %v, but got: %v", expected, errs)
+	}
+}
+
+type demoWrappedGuest struct {
+	Name string `validate:"required"`
+}
+
+// Validate wraps the valy validation, so it should not be called by valy.
+func (g demoWrappedGuest) Validate() error {
+	errs, err := valy.Errors(g)
+	if err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func TestValidate_shouldNotCallTheValidateFunctionOfTheStruct(t *testing.T) {
+	err := demoWrappedGuest{}.Validate()
+	expected := valy.ValidationErrors{
+		{FieldName: "Name", Rule: "required", Param: "true", Value: "", Message: "the field Name should not be empty"},
+	}
+	var errs valy.ValidationErrors
+	if !errors.As(err, &errs) || !reflect.DeepEqual(errs, expected) {
+		t.Errorf("expected %v, but got: %v", expected, err)
+	}
+	if err := (demoWrappedGuest{Name: "cpapidas"}).Validate(); err != nil {
+		t.Errorf("expected nill err but got: %v", err)
+	}
+}
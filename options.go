@@ -0,0 +1,162 @@
+package valy
+
+import (
+	"github.com/cpapidas/valy/field"
+)
+
+// Option describes an option of the validation. The options are passed to the New function and the ValidateWith,
+// JValidateWith and ErrorsWith functions e.g. valy.ValidateWith(u, valy.KeyTag("json")).
+type Option func(*options)
+
+// options contains the configuration of a validation.
+type options struct {
+	// customErrors contains the custom errors as map[FieldPath]errorStringMessage.
+	customErrors map[string]string
+
+	// tagName is the name of the tag which contains the validation annotation e.g. "validate".
+	tagName string
+
+	// keyTag is the name of the tag which defines the keys of the errors e.g. "json".
+	// If it is empty the keys are the names of the struct fields.
+	keyTag string
+
+	// messages contains the messages of the errors by rule name.
+	messages map[string]string
+
+	// catalog contains the messages of the errors by locale and rule name.
+	catalog *Catalog
+
+	// locale is the default locale of the messages of the catalog.
+	locale string
+
+	// stopOnFirstError defines if the validation stops after the first error.
+	stopOnFirstError bool
+
+	// registry contains the custom rules which can be applied to the fields.
+	registry *field.Registry
+
+	// workers is the maximum number of goroutines of a validation. The validation is sequential if it is less than 2.
+	workers int
+}
+
+// newOptions initializes and returns the options of a validation.
+func newOptions(opts ...Option) *options {
+	o := &options{tagName: "validate"}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.registry == nil {
+		o.registry = rules.Extend()
+	}
+	return o
+}
+
+// CustomErrors sets the custom errors of the fields. The map's key is the path of the field and the value
+// is the error message, which overrides all the errors of the field.
+func CustomErrors(customErrors map[string]string) Option {
+	return func(o *options) {
+		o.customErrors = customErrors
+	}
+}
+
+// TagName sets the tag which contains the validation annotation. The default tag is "validate".
+func TagName(name string) Option {
+	return func(o *options) {
+		o.tagName = name
+	}
+}
+
+// KeyTag sets the tag which defines the keys of the errors, e.g. "json", "form", "yaml" or any other tag.
+// The first part of the tag is the key of the field, so options like omitempty are ignored. A field without
+// a name in the tag keeps the name of the struct field and a field with the tag "-" is not validated. The keys
+// of the nested fields are joined by dots e.g. "address.post_code", and the custom errors are keyed by them too.
+//
+// The embedded structs are promoted according to the encoding/json rules, so an embedded struct with
+// a name in the tag is validated as a nested struct.
+func KeyTag(tag string) Option {
+	return func(o *options) {
+		o.keyTag = tag
+	}
+}
+
+// Messages sets the messages of the errors by rule name, which override the default messages. A message
+// can contain the placeholders {field}, {param} and {value}, which are replaced by the path of the field,
+// the argument of the rule and the value of the field e.g.
+// valy.Messages(map[string]string{"min": "{field} should be at least {param}"})
+// A message can be keyed by the kind of the field and the rule e.g. "string.min", which overrides the message of
+// the rule for the fields of the kind. The kinds are string, number, bool, time, duration, collection and struct.
+func Messages(messages map[string]string) Option {
+	return func(o *options) {
+		o.messages = messages
+	}
+}
+
+// MessageCatalog sets the catalog of the messages by locale. The locale of the messages is defined by
+// the context of the validation, see ContextWithLocale, or else by the Locale option. The messages
+// of the Messages option override the messages of the catalog.
+func MessageCatalog(catalog *Catalog) Option {
+	return func(o *options) {
+		o.catalog = catalog
+	}
+}
+
+// Locale sets the default locale of the messages of the catalog e.g. "el-GR".
+func Locale(locale string) Option {
+	return func(o *options) {
+		o.locale = locale
+	}
+}
+
+// StopOnFirstError stops the validation after the first error, so at most one error is returned.
+func StopOnFirstError() Option {
+	return func(o *options) {
+		o.stopOnFirstError = true
+	}
+}
+
+// RuleRegistry sets the registry of the custom rules. The Validators with the same registry share their
+// custom rules. By default each Validator has its own registry, which contains the rules registered by
+// the RegisterRule function too.
+func RuleRegistry(registry *Registry) Option {
+	return func(o *options) {
+		o.registry = registry
+	}
+}
+
+// Concurrency sets the maximum number of goroutines, including the caller's goroutine, which validate the
+// fields of a struct and the elements of the slices, arrays and maps of each validation concurrently. It is
+// useful if the custom rules are I/O bound, e.g. they query a database, so the custom rules and the struct
+// hooks should be safe for concurrent use. The errors are collected in the same order as the sequential
+// validation, so the result does not depend on the scheduling. By default the validation is sequential.
+//
+// HOW TO USE IT
+// validator := valy.New(valy.Concurrency(8))
+// errs, err := validator.Validate(batch)
+func Concurrency(workers int) Option {
+	return func(o *options) {
+		o.workers = workers
+	}
+}
+
+// messagesOf returns the messages of the errors by rule name for the locale, or for the default locale
+// if the locale is empty.
+func (o *options) messagesOf(locale string) map[string]string {
+	if o.catalog == nil {
+		return o.messages
+	}
+	if locale == "" {
+		locale = o.locale
+	}
+	messages := o.catalog.Messages(locale)
+	if len(o.messages) == 0 {
+		return messages
+	}
+	merged := make(map[string]string, len(messages)+len(o.messages))
+	for rule, message := range messages {
+		merged[rule] = message
+	}
+	for rule, message := range o.messages {
+		merged[rule] = message
+	}
+	return merged
+}
@@ -0,0 +1,109 @@
+package valy_test
+
+import (
+	"github.com/cpapidas/valy"
+	"reflect"
+	"testing"
+)
+
+type demoJsonAddress struct {
+	PostCode string `json:"post_code,omitempty" validate:"min=5"`
+}
+
+type demoJsonBase struct {
+	ID int `json:"id" validate:"nonzero"`
+}
+
+type demoJsonMeta struct {
+	Version int `validate:"nonzero"`
+}
+
+type demoJsonUser struct {
+	demoJsonBase
+	demoJsonMeta `json:"meta"`
+	Username     string            `json:"username" validate:"required=true"`
+	Nickname     string            `json:",omitempty" validate:"max=3"`
+	Password     string            `json:"-" validate:"required=true"`
+	Address      demoJsonAddress   `json:"address"`
+	Addresses    []demoJsonAddress `json:"addresses"`
+}
+
+func TestValidateWith_shouldUseTheKeyTagAsErrorKeys(t *testing.T) {
+	d := demoJsonUser{
+		Nickname:  "cpapidas",
+		Address:   demoJsonAddress{PostCode: "123"},
+		Addresses: []demoJsonAddress{{PostCode: "12345"}, {PostCode: "1"}},
+	}
+	errs, err := valy.ValidateWith(d, valy.KeyTag("json"))
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	expected := map[string][]string{
+		"id":                     {"the field id should not be zero"},
+		"meta.Version":           {"the field meta.Version should not be zero"},
+		"username":               {"the field username should not be empty"},
+		"Nickname":               {"the field Nickname should contains max 3 characters"},
+		"address.post_code":      {"the field address.post_code should contains at least 5 characters"},
+		"addresses[1].post_code": {"the field addresses[1].post_code should contains at least 5 characters"},
+	}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("expected %v, but got: %v", expected, errs)
+	}
+}
+
+func TestJValidateWith_shouldUseTheKeyTagAndCustomErrors(t *testing.T) {
+	d := demoJsonUser{
+		demoJsonBase: demoJsonBase{ID: 1},
+		demoJsonMeta: demoJsonMeta{Version: 1},
+		Address:      demoJsonAddress{PostCode: "12345"},
+	}
+	errs, err := valy.JValidateWith(d, valy.KeyTag("json"),
+		valy.CustomErrors(map[string]string{"username": "username is required"}))
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	expected := `{"username":["username is required"]}`
+	if string(errs) != expected {
+		t.Errorf("expected %s, but got: %s", expected, errs)
+	}
+}
+
+type demoAmbiguous struct {
+	demoJsonBase
+	demoCustomID
+}
+
+type demoCustomID struct {
+	ID int `json:"identifier" validate:"nonzero"`
+}
+
+type demoTaggedAmbiguous struct {
+	demoBase
+	demoTaggedID
+}
+
+type demoTaggedID struct {
+	Identifier int `json:"ID" validate:"min=10"`
+}
+
+func TestValidateWith_shouldPromoteTheTaggedFields(t *testing.T) {
+	errs, err := valy.ValidateWith(demoTaggedAmbiguous{}, valy.KeyTag("json"))
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	expected := map[string][]string{"ID": {"the field ID should be grater than 10"}}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("expected %v, but got: %v", expected, errs)
+	}
+	errs, err = valy.ValidateWith(demoAmbiguous{}, valy.KeyTag("json"))
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	expected = map[string][]string{
+		"id":         {"the field id should not be zero"},
+		"identifier": {"the field identifier should not be zero"},
+	}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("expected %v, but got: %v", expected, errs)
+	}
+}
@@ -0,0 +1,253 @@
+package valy
+
+import (
+	"errors"
+	"github.com/cpapidas/valy/field"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// plans contains the compiled plans of the struct types by planKey.
+var plans sync.Map
+
+// nodes contains the compiled nodes of the dynamic types of the interface values by nodeKey.
+var nodes sync.Map
+
+// planKey describes the key of a compiled plan. The plan of a struct type depends on
+// the tag name and the key tag, so a type is compiled once for each of them.
+type planKey struct {
+	typ     reflect.Type
+	tagName string
+	keyTag  string
+}
+
+// nodeKey describes the key of a compiled node of a dynamic type.
+type nodeKey struct {
+	typ         reflect.Type
+	validations string
+}
+
+// plan describes the compiled validations of a struct type. The annotations of the struct
+// fields are parsed once, so the plan can validate any value of the struct type.
+type plan struct {
+	// fields contains the compiled fields in declaration order.
+	fields []fieldPlan
+
+	// hooks defines if the struct type, or its pointer type, implements StructValidator or Validatable.
+	hooks bool
+}
+
+// fieldPlan describes the compiled validations of a struct field.
+type fieldPlan struct {
+	// name is the key of the field in the errors.
+	name string
+
+	// index is the index sequence of the field.
+	index []int
+
+	// typ is the type of the field.
+	typ reflect.Type
+
+	// node contains the compiled validations of the field's value.
+	node *node
+}
+
+// node describes the compiled validations of a value of a type.
+//
+// For example the annotation `validate:"max=3,dive,keys,min=2,endkeys,min=1"` of a
+// map[string]int field is compiled to:
+//
+// node{rules: "max=3", key: &node{rules: "min=2"}, elem: &node{rules: "min=1"}}
+type node struct {
+	// rules contains the compiled rules of the value. It is nil if the value has no rules.
+	rules *field.Compiled
+
+	// zero is the zero value of the type, after dereferencing the pointers. It is the value of a nil field.
+	zero reflect.Value
+
+	// validations contains the rules of an interface value. They are compiled for the dynamic type of
+	// the value, by the dynamic function. The zero value of an interface node is an interface.
+	validations []string
+
+	// tag contains the validations of an interface value joined by NUL characters. It is the key of the dynamic nodes.
+	tag string
+
+	// elem contains the compiled validations of the elements. It is nil if the elements are not validated.
+	elem *node
+
+	// key contains the compiled validations of the map keys. It is nil if the keys are not validated.
+	key *node
+}
+
+// planOf returns the compiled plan of the struct type t according to the options. The plan is
+// compiled once and it is cached.
+func planOf(t reflect.Type, o *options) (*plan, error) {
+	k := planKey{typ: t, tagName: o.tagName, keyTag: o.keyTag}
+	if p, ok := plans.Load(k); ok {
+		return p.(*plan), nil
+	}
+	p := &plan{hooks: implementsHooks(t) || implementsHooks(reflect.PtrTo(t))}
+	for _, sf := range structFields(t, o.tagName, o.keyTag) {
+		validations, err := field.ParseTag(sf.tag)
+		if err == nil {
+			err = field.CheckFieldPaths(t, validations)
+		}
+		if err != nil {
+			return nil, errors.New("Invalid rules of the field " + t.String() + "." + sf.name + ": " + err.Error())
+		}
+		n, err := compileNode(sf.typ, validations)
+		if err != nil {
+			return nil, errors.New("Invalid rules of the field " + t.String() + "." + sf.name + ": " + err.Error())
+		}
+		p.fields = append(p.fields, fieldPlan{name: sf.name, index: sf.index, typ: sf.typ, node: n})
+	}
+	actual, _ := plans.LoadOrStore(k, p)
+	return actual.(*plan), nil
+}
+
+// implementsHooks reports whether the type t implements StructValidator or Validatable.
+func implementsHooks(t reflect.Type) bool {
+	return t.Implements(structValidatorType) || t.Implements(validatableType)
+}
+
+// structValidatorType and validatableType are the types of the StructValidator and Validatable interfaces.
+var (
+	structValidatorType = reflect.TypeOf((*StructValidator)(nil)).Elem()
+	validatableType     = reflect.TypeOf((*Validatable)(nil)).Elem()
+)
+
+// compileNode compiles the validations of a value of the type t.
+//
+// The pointers are dereferenced, so the rules are compiled for the pointed type. The validations after the
+// dive rule are compiled for the elements of the slices, arrays and maps, and the validations between the keys
+// and endkeys rules for the map keys. The elements of the collections which contain structs are always compiled.
+func compileNode(t reflect.Type, validations []string) (*node, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	rules, elemRules, keyRules, err := splitDive(validations)
+	if err != nil {
+		return nil, err
+	}
+	n := &node{zero: reflect.Zero(t)}
+	if t.Kind() == reflect.Interface {
+		n.validations = validations
+		n.tag = strings.Join(validations, "\x00")
+	}
+	if len(rules) > 0 {
+		if n.rules, err = field.Compile(t.String(), n.zero.Interface(), rules); err != nil {
+			return nil, err
+		}
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if elemRules == nil && !hasStructs(t) {
+			return n, nil
+		}
+		if n.elem, err = compileNode(t.Elem(), elemRules); err != nil {
+			return nil, err
+		}
+		if t.Kind() == reflect.Map && keyRules != nil {
+			if n.key, err = compileNode(t.Key(), keyRules); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return n, nil
+}
+
+// dynamic returns the compiled node of the interface value's dynamic type t. The node is compiled
+// once for each dynamic type and it is cached.
+func (n *node) dynamic(t reflect.Type) (*node, error) {
+	k := nodeKey{typ: t, validations: n.tag}
+	if dn, ok := nodes.Load(k); ok {
+		return dn.(*node), nil
+	}
+	dn, err := compileNode(t, n.validations)
+	if err != nil {
+		return nil, err
+	}
+	actual, _ := nodes.LoadOrStore(k, dn)
+	return actual.(*node), nil
+}
+
+// Prepare compiles the validations of the data's struct type, and the types of its nested structs, according to
+// the options. The compiled validations are cached, so the validation of the type is faster the first time too.
+// If any annotation of the types is not valid then it returns an error, e.g. for an unknown rule or a builtin
+// rule which can not be applied to the type of the field.
+//
+// HOW TO USE IT
+// Prepare the types at the start of the application, e.g. in an init function:
+// if err := valy.Prepare(demoUser{}, valy.KeyTag("json")); err != nil {
+// 	panic(err)
+// }
+func Prepare(data interface{}, opts ...Option) error {
+	return New(opts...).Prepare(data)
+}
+
+// Compile compiles the validations of the struct type T, the same way as Prepare.
+//
+// HOW TO USE IT
+// if err := valy.Compile[demoUser](valy.KeyTag("json")); err != nil {
+// 	panic(err)
+// }
+func Compile[T any](opts ...Option) error {
+	return prepareType(reflect.TypeOf((*T)(nil)).Elem(), newOptions(opts...))
+}
+
+// prepareType compiles the validations of the struct type t, or the type t points to, according to the options.
+func prepareType(t reflect.Type, o *options) error {
+	st := t
+	for st.Kind() == reflect.Ptr {
+		st = st.Elem()
+	}
+	if st.Kind() != reflect.Struct {
+		return errors.New("Cannot support " + t.String() + " type, the data should be a struct")
+	}
+	return prepare(st, o, make(map[reflect.Type]bool))
+}
+
+// prepare compiles the plan of the struct type t and the plans of the struct types
+// which are contained by its fields, and checks that their custom rules are registered. The visited contains the
+// prepared types.
+func prepare(t reflect.Type, o *options, visited map[reflect.Type]bool) error {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || visited[t] {
+		return nil
+	}
+	visited[t] = true
+	p, err := planOf(t, o)
+	if err != nil {
+		return err
+	}
+	for _, f := range p.fields {
+		if err := f.node.checkRules(o.registry); err != nil {
+			return errors.New("Invalid rules of the field " + t.String() + "." + f.name + ": " + err.Error())
+		}
+		if err := prepare(f.typ, o, visited); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkRules returns an error if any rule of the node, or of its elements and keys, is neither a builtin rule
+// nor a custom rule of the registry. The plans are shared by the Validators, so the rules are checked against
+// the registry of each Validator.
+func (n *node) checkRules(registry *field.Registry) error {
+	if n == nil {
+		return nil
+	}
+	if n.rules != nil {
+		if err := n.rules.CheckRules(registry); err != nil {
+			return err
+		}
+	}
+	if err := n.elem.checkRules(registry); err != nil {
+		return err
+	}
+	return n.key.checkRules(registry)
+}
@@ -0,0 +1,189 @@
+package valy_test
+
+import (
+	"github.com/cpapidas/valy"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type demoInvalidNested struct {
+	Items []demoInvalidNumericValidation
+}
+
+func TestPrepare_shouldReturnErrorForInvalidRules(t *testing.T) {
+	if err := valy.Prepare(demoInvalidNested{}); err == nil {
+		t.Error("expected an error for the invalid rules of the nested struct but got nil")
+	}
+	if err := valy.Prepare("a string"); err == nil {
+		t.Error("expected an error for the non struct value but got nil")
+	}
+	if err := valy.Prepare(nil); err == nil {
+		t.Error("expected an error for the nil value but got nil")
+	}
+}
+
+func TestPrepare_shouldReturnErrorForUnknownAndNotApplicableRules(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     interface{}
+		expected string
+	}{
+		{"unknown rule", struct {
+			IBAN string `validate:"ibanx"`
+		}{}, "the rule ibanx is unknown"},
+		{"email of an int", struct {
+			Code int `validate:"email"`
+		}{}, "the rule email can not be applied to the int field"},
+		{"before of an int", struct {
+			Code int `validate:"before=now"`
+		}{}, "the rule before can not be applied to the int field"},
+		{"positive of a string", struct {
+			Code string `validate:"positive"`
+		}{}, "the rule positive can not be applied to the string field"},
+		{"multiple_of of a string", struct {
+			Code string `validate:"multiple_of=2"`
+		}{}, "the rule multiple_of can not be applied to the string field"},
+		{"accepted of a string", struct {
+			Code string `validate:"accepted"`
+		}{}, "the rule accepted can not be applied to the string field"},
+		{"min of a time", struct {
+			At time.Time `validate:"min=3"`
+		}{}, "the rule min can not be applied to the time.Time field"},
+		{"email of a time", struct {
+			At *time.Time `validate:"email"`
+		}{}, "the rule email can not be applied to the time.Time field"},
+		{"min of a struct", struct {
+			Address demoAddress `validate:"min=1"`
+		}{}, "the rule min can not be applied to the valy_test.demoAddress field"},
+		{"min of a func", struct {
+			Fn func() `validate:"min=1"`
+		}{}, "Cannot support func() field type"},
+	}
+	for _, tt := range tests {
+		err := valy.Prepare(tt.data)
+		if err == nil || !strings.Contains(err.Error(), tt.expected) {
+			t.Errorf("%s: expected the error %s, but got: %v", tt.name, tt.expected, err)
+		}
+	}
+	vl := valy.New()
+	if err := vl.RegisterRule("ibanx", func(c valy.Check) (bool, error) { return true, nil }); err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	if err := vl.Prepare(tests[0].data); err != nil {
+		t.Errorf("expected nill err for the registered rule but got: %v", err)
+	}
+}
+
+type demoInvalidTag struct {
+	Color string `validate:"required,oneof='red green"`
+}
+
+func TestPrepare_shouldReturnTheColumnOfInvalidTags(t *testing.T) {
+	err := valy.Prepare(demoInvalidTag{})
+	expected := "Invalid rules of the field valy_test.demoInvalidTag.Color: syntax error at column 16: unterminated quoted param"
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected the error %s, but got: %v", expected, err)
+	}
+}
+
+type demoQuotedTag struct {
+	Color    string `validate:"required, oneof='red green blue'"`
+	Greeting string `validate:"required,Err='Hello, world'"`
+}
+
+func TestValidate_shouldApplyTheQuotedParams(t *testing.T) {
+	errs, err := valy.Validate(demoQuotedTag{Color: "black", Greeting: "hi"})
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	expected := map[string][]string{"Color": {"the field Color should be one of red, green, blue"}}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("expected %v, but got: %v", expected, errs)
+	}
+}
+
+func TestPrepare_shouldCompileValidTypes(t *testing.T) {
+	if err := valy.Prepare(&demoCustomer{}, valy.KeyTag("json")); err != nil {
+		t.Errorf("expected nill err but got: %v", err)
+	}
+	if err := valy.Compile[demoCollections](); err != nil {
+		t.Errorf("expected nill err but got: %v", err)
+	}
+	if err := valy.Compile[[]demoCollections](); err == nil {
+		t.Error("expected an error for the non struct type but got nil")
+	}
+}
+
+type demoInterface struct {
+	Value interface{} `validate:"required=true,min=3"`
+	Items interface{} `validate:"dive,min=3"`
+}
+
+func TestValidate_shouldValidateInterfacesByTheirDynamicType(t *testing.T) {
+	errs, err := valy.Validate(demoInterface{Value: "ab", Items: []int{5, 1}})
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	expected := map[string][]string{
+		"Value":    {"the field Value should contains at least 3 characters"},
+		"Items[1]": {"the field Items[1] should be grater than 3"},
+	}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("expected %v, but got: %v", expected, errs)
+	}
+	errs, err = valy.Validate(demoInterface{Value: 2})
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	expected = map[string][]string{"Value": {"the field Value should be grater than 3"}}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("expected %v, but got: %v", expected, errs)
+	}
+	errs, err = valy.Validate(demoInterface{})
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	expected = map[string][]string{"Value": {"the field Value should not be empty"}}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("expected %v, but got: %v", expected, errs)
+	}
+}
+
+func TestValidate_shouldBeSafeForConcurrentUse(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs, err := valy.Validate(demoOrdered{Zone: "EUR"})
+			if err != nil || len(errs) != 3 {
+				t.Errorf("expected 3 errors but got: %v, %v", errs, err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func BenchmarkValidate(b *testing.B) {
+	u := demoUser{Username: "cpapidas", Phone: "1234567891011"}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := valy.Validate(u); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkValidate_nested(b *testing.B) {
+	c := demoCustomer{Name: "cpapidas"}
+	c.Address = demoAddress{Street: "Main", PostCode: "123"}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := valy.Validate(c); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package valy
+
+import (
+	"github.com/cpapidas/valy/field"
+)
+
+// rules contains the custom rules registered by RegisterRule.
+var rules = field.NewRegistry()
+
+// Registry contains the custom rules by name. It is safe for concurrent use.
+type Registry = field.Registry
+
+// NewRegistry initializes and returns a Registry which contains the rules registered by the
+// RegisterRule function too. A Registry can be shared by many Validators by the RuleRegistry option.
+func NewRegistry() *Registry {
+	return rules.Extend()
+}
+
+// Check describes the input of a custom rule. It contains the field's path, value, the rule's
+// argument and the struct which contains the field.
+type Check = field.Check
+
+// RuleFunc describes a custom rule. It returns false if the value of the field is not valid.
+type RuleFunc = field.RuleFunc
+
+// RegisterRule registers the custom rule fn under the given name. The custom rules can be used in the
+// annotations alongside the builtin rules, the argument of the rule is passed to fn as Check.Param.
+// It returns an error if the name is not valid or it is the name of a builtin rule.
+//
+// HOW TO USE IT
+// Register the rule once, e.g. in an init function:
+// err := valy.RegisterRule("sku", func(c valy.Check) (bool, error) {
+// 	s, _ := c.Value.(string)
+// 	return strings.HasPrefix(s, c.Param+"-"), nil
+// })
+// And use it in the annotation of the field:
+// type product struct {
+// 	SKU string `validate:"required=true,sku=EU"`
+// }
+func RegisterRule(name string, fn RuleFunc) error {
+	return rules.Register(name, fn)
+}
+
+// Decimal describes a decimal number type, e.g. the type of a decimal package, which is validated by the numeric
+// rules the same way as the math/big numbers. The Rat function returns the exact value of the number.
+type Decimal = field.Decimal
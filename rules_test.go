@@ -0,0 +1,127 @@
+package valy_test
+
+import (
+	"errors"
+	"github.com/cpapidas/valy"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func init() {
+	_ = valy.RegisterRule("sku", func(c valy.Check) (bool, error) {
+		s, _ := c.Value.(string)
+		return strings.HasPrefix(s, c.Param+"-"), nil
+	})
+	_ = valy.RegisterRule("tenant", func(c valy.Check) (bool, error) {
+		o, ok := c.Parent.(demoOrder)
+		if !ok {
+			return false, errors.New("the tenant rule should be used in demoOrder")
+		}
+		return c.Value.(int) == o.TenantID, nil
+	})
+	_ = valy.RegisterRule("approved", func(c valy.Check) (bool, error) {
+		b, _ := c.Value.(bool)
+		return b, nil
+	})
+	_ = valy.RegisterRule("failing", func(c valy.Check) (bool, error) {
+		return false, errors.New("cannot check the rule")
+	})
+}
+
+type demoOrder struct {
+	TenantID  int
+	SKU       string `validate:"required=true,sku=EU"`
+	Customer  int    `validate:"tenant=true"`
+	Confirmed bool   `validate:"approved=true"`
+}
+
+func TestRegisterRule_shouldApplyCustomRules(t *testing.T) {
+	errs, err := valy.Validate(demoOrder{TenantID: 1, SKU: "US-1234", Customer: 2})
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	expected := map[string][]string{
+		"SKU":       {"the field SKU does not satisfy the sku rule"},
+		"Customer":  {"the field Customer does not satisfy the tenant rule"},
+		"Confirmed": {"the field Confirmed does not satisfy the approved rule"},
+	}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("expected %v, but got: %v", expected, errs)
+	}
+}
+
+func TestRegisterRule_shouldSkipCustomRulesOfNotProvidedFields(t *testing.T) {
+	type demoPointer struct {
+		SKU *string `validate:"sku=EU"`
+	}
+	errs, err := valy.Validate(demoPointer{})
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	if len(errs) != 0 {
+		t.Errorf("expected no errors, but got: %v", errs)
+	}
+}
+
+func TestRegisterRule_shouldReturnTheErrorOfTheRule(t *testing.T) {
+	type demoFailing struct {
+		Demo string `validate:"failing=true"`
+	}
+	if _, err := valy.Validate(demoFailing{}); err == nil {
+		t.Error("error should not be nil")
+	}
+}
+
+func TestRegisterRule_shouldNotRegisterInvalidRules(t *testing.T) {
+	fn := func(c valy.Check) (bool, error) { return true, nil }
+	if err := valy.RegisterRule("min", fn); err == nil {
+		t.Error("expected an error for the builtin rule but got nil")
+	}
+	if err := valy.RegisterRule("err.min", fn); err == nil {
+		t.Error("expected an error for the err rule but got nil")
+	}
+	if err := valy.RegisterRule("", fn); err == nil {
+		t.Error("expected an error for the empty name but got nil")
+	}
+	if err := valy.RegisterRule("demo", nil); err == nil {
+		t.Error("expected an error for the nil function but got nil")
+	}
+}
+
+func TestRegisterRule_shouldReturnAnErrorForUnknownRules(t *testing.T) {
+	type demoUnknown struct {
+		IBAN string `validate:"iban=true"`
+	}
+	_, err := valy.Validate(demoUnknown{IBAN: "GR16"})
+	if err == nil || !strings.Contains(err.Error(), "unknown rule iban") {
+		t.Errorf("expected the unknown rule error, but got: %v", err)
+	}
+}
+
+func TestRegisterRule_shouldApplyCustomRulesToStructFields(t *testing.T) {
+	type demoAddressOrder struct {
+		Address demoAddress `validate:"addrcheck"`
+	}
+	vl := valy.New()
+	err := vl.RegisterRule("addrcheck", func(c valy.Check) (bool, error) {
+		a, ok := c.Value.(demoAddress)
+		return ok && strings.HasPrefix(a.PostCode, "1"), nil
+	})
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	errs, err := vl.Validate(demoAddressOrder{Address: demoAddress{Street: "Main", PostCode: "54321"}})
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	expected := map[string][]string{
+		"Address": {"the field Address does not satisfy the addrcheck rule"},
+	}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("expected %v, but got: %v", expected, errs)
+	}
+	if _, err := valy.Validate(demoAddressOrder{}); err == nil || !strings.Contains(err.Error(), "unknown rule addrcheck") {
+		t.Errorf("expected the unknown rule error, but got: %v", err)
+	}
+}
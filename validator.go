@@ -0,0 +1,111 @@
+package valy
+
+import (
+	"context"
+	"errors"
+	"reflect"
+)
+
+// Validator validates the structs according to its options. A Validator is safe for concurrent use, so
+// it can be created once, e.g. for each service, and be used by many goroutines.
+//
+// HOW TO USE IT
+// Create a Validator with the options of the service:
+// validator := valy.New(valy.TagName("valid"), valy.KeyTag("json"), valy.StopOnFirstError())
+// if err := validator.RegisterRule("sku", skuRule); err != nil {
+// 	panic(err)
+// }
+// errs, err := validator.Validate(du)
+type Validator struct {
+	// o contains the options of the Validator.
+	o *options
+}
+
+// New initializes and returns a Validator configured by the options.
+func New(opts ...Option) *Validator {
+	return &Validator{o: newOptions(opts...)}
+}
+
+// Validate validates the data which is a struct, or a pointer to struct. The function will return a
+// map[string][]string object. The map's key is the name of the property and the value is an array of strings
+// that contains all the errors.
+func (vl *Validator) Validate(data interface{}) (map[string][]string, error) {
+	return vl.ValidateCtx(context.Background(), data)
+}
+
+// ValidateCtx validates the data the same way as Validate, with the context of ErrorsCtx.
+func (vl *Validator) ValidateCtx(ctx context.Context, data interface{}) (map[string][]string, error) {
+	validationErrs, err := vl.ErrorsCtx(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	return validationErrs.Map(), nil
+}
+
+// JValidate validates the data which is a struct, or a pointer to struct. The function will return the errors
+// as JSON []byte, or nil if the data is valid.
+func (vl *Validator) JValidate(data interface{}) ([]byte, error) {
+	return vl.JValidateCtx(context.Background(), data)
+}
+
+// JValidateCtx validates the data the same way as JValidate, with the context of ErrorsCtx.
+func (vl *Validator) JValidateCtx(ctx context.Context, data interface{}) ([]byte, error) {
+	validationErrs, err := vl.ErrorsCtx(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	if len(validationErrs) == 0 {
+		return nil, nil
+	}
+	return validationErrs.jsonObject()
+}
+
+// Errors validates the data which is a struct, or a pointer to struct. The function will return the errors
+// as ValidationErrors and if something go wrong it returns the nil and error.
+func (vl *Validator) Errors(data interface{}) (ValidationErrors, error) {
+	return vl.ErrorsCtx(context.Background(), data)
+}
+
+// ErrorsCtx validates the data the same way as Errors. The context is passed to the custom rules and the
+// struct hooks, and the validation stops with the error of the context if the context is done. The locale
+// of the messages is defined by the context, see ContextWithLocale.
+func (vl *Validator) ErrorsCtx(ctx context.Context, data interface{}) (ValidationErrors, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	d := reflect.ValueOf(data)
+	for d.Kind() == reflect.Ptr {
+		d = d.Elem()
+	}
+	if d.Kind() != reflect.Struct {
+		if !d.IsValid() {
+			return nil, errors.New("Cannot validate a nil value")
+		}
+		return nil, errors.New("Cannot support " + d.Type().String() + " type, the data should be a struct")
+	}
+	p := &parser{o: vl.o, root: d, ctx: ctx, messages: vl.o.messagesOf(LocaleFromContext(ctx))}
+	if vl.o.workers > 1 {
+		p.workers = make(chan struct{}, vl.o.workers-1)
+	}
+	if err := p.parseFields(d, ""); err != nil && err != errStop {
+		return nil, err
+	}
+	return p.errs, nil
+}
+
+// Prepare compiles the validations of the data's struct type, and the types of its nested structs, the same
+// way as the Prepare function.
+func (vl *Validator) Prepare(data interface{}) error {
+	t := reflect.TypeOf(data)
+	if t == nil {
+		return errors.New("Cannot prepare a nil value")
+	}
+	return prepareType(t, vl.o)
+}
+
+// RegisterRule registers the custom rule fn under the given name to the rules of the Validator, the same way
+// as the RegisterRule function. The rule is not visible to the rest Validators, unless they share the same
+// registry by the RuleRegistry option.
+func (vl *Validator) RegisterRule(name string, fn RuleFunc) error {
+	return vl.o.registry.Register(name, fn)
+}
@@ -0,0 +1,125 @@
+package valy_test
+
+import (
+	"github.com/cpapidas/valy"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type demoValidatorUser struct {
+	Username string `valid:"required=true,min=5" validate:"max=2"`
+	Code     string `valid:"region=EU" json:"code"`
+	Age      int    `valid:"min=18"`
+}
+
+// demoRegionValidator returns a Validator with the region rule.
+func demoRegionValidator(t *testing.T, opts ...valy.Option) *valy.Validator {
+	vl := valy.New(opts...)
+	err := vl.RegisterRule("region", func(c valy.Check) (bool, error) {
+		s, _ := c.Value.(string)
+		return strings.HasPrefix(s, c.Param), nil
+	})
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	return vl
+}
+
+func TestValidator_shouldUseItsOwnTagNameAndRegistry(t *testing.T) {
+	vl := demoRegionValidator(t, valy.TagName("valid"), valy.KeyTag("json"))
+	errs, err := vl.Validate(demoValidatorUser{Username: "cp", Code: "US-1", Age: 20})
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	expected := map[string][]string{
+		"Username": {"the field Username should contains at least 5 characters"},
+		"code":     {"the field code does not satisfy the region rule"},
+	}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("expected %v, but got: %v", expected, errs)
+	}
+
+	// The rule is registered only to the Validator, so it is unknown to the rest Validators.
+	_, err = valy.New(valy.TagName("valid")).Validate(demoValidatorUser{Username: "cpapidas", Code: "US-1", Age: 20})
+	if err == nil || !strings.Contains(err.Error(), "unknown rule region") {
+		t.Errorf("expected the unknown rule error, but got: %v", err)
+	}
+}
+
+func TestValidator_shouldShareTheRuleRegistry(t *testing.T) {
+	registry := valy.NewRegistry()
+	err := registry.Register("region", func(c valy.Check) (bool, error) {
+		s, _ := c.Value.(string)
+		return strings.HasPrefix(s, c.Param), nil
+	})
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	for _, vl := range []*valy.Validator{
+		valy.New(valy.TagName("valid"), valy.RuleRegistry(registry)),
+		valy.New(valy.TagName("valid"), valy.RuleRegistry(registry), valy.KeyTag("json")),
+	} {
+		errs, err := vl.Errors(demoValidatorUser{Username: "cpapidas", Code: "US-1", Age: 20})
+		if err != nil {
+			t.Fatalf("expected nill err but got: %v", err)
+		}
+		if len(errs) != 1 || errs[0].Rule != "region" {
+			t.Errorf("expected the region error, but got: %v", errs)
+		}
+	}
+}
+
+func TestValidator_shouldStopOnFirstError(t *testing.T) {
+	errs, err := valy.New(valy.TagName("valid"), valy.StopOnFirstError()).Errors(&demoValidatorUser{Code: "EU", Age: 1})
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	expected := valy.ValidationErrors{
+		{FieldName: "Username", Rule: "required", Param: "true", Value: "", Message: "the field Username should not be empty"},
+	}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("expected %v, but got: %v", expected, errs)
+	}
+}
+
+func TestValidator_shouldRenderTheMessages(t *testing.T) {
+	vl := demoRegionValidator(t, valy.TagName("valid"), valy.Messages(map[string]string{
+		"min": "{field} should be at least {param}, not {value}",
+	}))
+	errs, err := vl.JValidate(demoValidatorUser{Username: "cpapidas", Code: "EU", Age: 5})
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	expected := `{"Age":["Age should be at least 18, not 5"]}`
+	if string(errs) != expected {
+		t.Errorf("expected %v, but got: %v", expected, string(errs))
+	}
+}
+
+func TestValidator_shouldBeSafeForConcurrentUse(t *testing.T) {
+	vl := demoRegionValidator(t, valy.TagName("valid"), valy.KeyTag("json"))
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs, err := vl.Validate(demoValidatorUser{Username: "cp", Age: 20})
+			if err != nil || len(errs["Username"]) != 1 {
+				t.Errorf("expected the Username error, but got: %v, %v", errs, err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestValidator_shouldReturnAnErrorForNilData(t *testing.T) {
+	vl := valy.New()
+	if _, err := vl.Validate(nil); err == nil {
+		t.Error("expected an error for nil data")
+	}
+	if err := vl.Prepare(nil); err == nil {
+		t.Error("expected an error for nil data")
+	}
+}
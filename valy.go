@@ -1,21 +1,25 @@
 package valy
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
+	"fmt"
 	"github.com/cpapidas/valy/field"
+	"math/big"
 	"reflect"
-	"strings"
+	"strconv"
 )
 
-// Validate gets two parameters the data (required) which is a struct of data to validate and the CustomErrors which
-// is an optional parameters of map[string]string. The function will return a map[string][]string object. The map's key
-// is the name of the property and the value is an array of strings that contains all the errors.
+// Validate gets two parameters the data (required) which is a struct, or a pointer to struct, of data to validate
+// and the CustomErrors which is an optional parameters of map[string]string. The function will return a
+// map[string][]string object. The map's key is the name of the property and the value is an array of strings that
+// contains all the errors.
 //
 // HOW TO USE IT
 // Define and initialize the demoUser struct, after it call the Validate function:
 // type demoUser struct {
 //  	Username string `validate:"required=true,min=10,max=55"`
-//  	Password string `validate:"required=true,Err="Password is required"`
+//  	Password string `validate:"required=true,err='Password is required'"`
 //  	Age      int    `validate:"required=true,min=10,max=99"`
 //  }
 // du := demoUser{
@@ -29,7 +33,8 @@ import (
 // ERROR MESSAGES
 // The Valy validator give us three ways to set the errors messages:
 // 	1) The defaults. Valy contains default error messages for all supported validations.
-//  2) Custom error message using the property Err="The error message" e.g. `validate:"required=true,Err="Password is required"`
+//  2) Custom error message using the property err='The error message' e.g. `validate:"required=true,err='Password is required'"`
+//     or the message of a rule using the property err.rule e.g. `validate:"min=8,err.min='{field} should contain {param} characters'"`
 //  3) Add the optional parameter CustomErrors type of map[string]string when we call the Validate function. Custom errors give
 // 		us the flexibility to have translatable error messages.
 //      Example of custom error messages:
@@ -38,17 +43,18 @@ import (
 //		}
 //		errs := valy.Validate(u, errMess)
 func Validate(data interface{}, customErrors ...map[string]string) (map[string][]string, error) {
-	return v(data, customErrors...)
+	return ValidateWith(data, customErrorsOptions(customErrors)...)
 }
 
 // JValidate gets two parameters the data (required) which is a struct of data to validate and the CustomErrors which
 // is an optional parameters of map[string]string. The function will return the errors as JSON []byte.
+// The fields of the JSON object are ordered by the declaration order of the struct fields.
 //
 // HOW TO USE IT
 // Define and initialize the demoUser struct and call the JValidate function:
 // type demoUser struct {
 //  	Username string `validate:"required=true,min=10,max=55"`
-//  	Password string `validate:"required=true,Err="Password is required"`
+//  	Password string `validate:"required=true,err='Password is required'"`
 //  	Age      int   `validate:"required=true,min=10,max=99"`
 //  }
 // du := demoUser{
@@ -62,7 +68,8 @@ func Validate(data interface{}, customErrors ...map[string]string) (map[string][
 // ERROR MESSAGES
 // The Valy validator give us three ways to set the errors messages:
 // 	1) The defaults. Valy contains default error messages for all supported validations.
-//  2) Custom error message using the property Err="The error message" e.g. `validate:"required=true,Err="Password is required"`
+//  2) Custom error message using the property err='The error message' e.g. `validate:"required=true,err='Password is required'"`
+//     or the message of a rule using the property err.rule e.g. `validate:"min=8,err.min='{field} should contain {param} characters'"`
 //  3) Add the optional parameter CustomErrors type of map[string]string when we call the Validate function. Custom errors give
 // 		us the flexibility to have translatable error messages.
 //      Example of custom error messages:
@@ -71,58 +78,259 @@ func Validate(data interface{}, customErrors ...map[string]string) (map[string][
 //		}
 //		errs := valy.JValidate(u, errMess)
 func JValidate(data interface{}, customErrors ...map[string]string) ([]byte, error) {
-	validationErrs, err := v(data, customErrors...)
-	if err != nil {
-		return nil, err
-	}
-	if len(validationErrs) == 0 {
-		return nil, nil
-	}
+	return JValidateWith(data, customErrorsOptions(customErrors)...)
+}
+
+// Errors gets two parameters the data (required) which is a struct of data to validate and the CustomErrors which
+// is an optional parameters of map[string]string. The function will return the errors as ValidationErrors.
+//
+// Each error of ValidationErrors describes a failed rule, so the callers can check which rule failed without
+// parsing the messages:
+// errs, err := valy.Errors(du)
+// for _, e := range errs {
+// 	fmt.Println(e.FieldName, e.Rule, e.Param, e.Value, e.Message)
+// }
+//
+// The ValidationErrors can be converted to the map[string][]string of the Validate function by calling errs.Map().
+func Errors(data interface{}, customErrors ...map[string]string) (ValidationErrors, error) {
+	return ErrorsWith(data, customErrorsOptions(customErrors)...)
+}
+
+// ValidateWith validates the data the same way as the Validate function, but it is configured by the options.
+//
+// HOW TO USE IT
+// Use the json tags as the keys of the errors:
+// type demoUser struct {
+//  	Username string `json:"username" validate:"required=true,min=10,max=55"`
+//  }
+// errs, err := valy.ValidateWith(du, valy.KeyTag("json"))
+// fmt.println(errs["username"])
+func ValidateWith(data interface{}, opts ...Option) (map[string][]string, error) {
+	return New(opts...).Validate(data)
+}
+
+// JValidateWith validates the data the same way as the JValidate function, but it is configured by the options.
+func JValidateWith(data interface{}, opts ...Option) ([]byte, error) {
+	return New(opts...).JValidate(data)
+}
 
-	s, _ := json.Marshal(validationErrs)
-	return s, nil
+// ErrorsWith validates the data the same way as the Errors function, but it is configured by the options.
+func ErrorsWith(data interface{}, opts ...Option) (ValidationErrors, error) {
+	return New(opts...).Errors(data)
 }
 
-// v is the private function which starts the validation. It gets two parameters the data which is a struct and the
-// optional parameter CustomErrors which is a map[FieldName]errorStringMessage.
+// ValidateCtx validates the data the same way as the ValidateWith function. The context is passed to the
+// custom rules and the struct hooks, and the validation stops with the error of the context, e.g.
+// context.DeadlineExceeded, if the context is done. The locale of the messages is defined by the context
+// too, see ContextWithLocale.
 //
-// It returns the errors of all fields as a map[string][]string and if something go wrong it returns
-// the nil and error.
-func v(data interface{}, customErrors ...map[string]string) (map[string][]string, error) {
-	var ce map[string]string
-	t := reflect.TypeOf(data)
-	v := reflect.ValueOf(data)
-	if len(customErrors) > 0 {
-		ce = customErrors[0]
+// HOW TO USE IT
+// ctx, cancel := context.WithTimeout(r.Context(), time.Second)
+// defer cancel()
+// errs, err := valy.ValidateCtx(context.WithValue(ctx, tenantKey{}, tenant), du, valy.KeyTag("json"))
+func ValidateCtx(ctx context.Context, data interface{}, opts ...Option) (map[string][]string, error) {
+	return New(opts...).ValidateCtx(ctx, data)
+}
+
+// JValidateCtx validates the data the same way as the ValidateCtx function and returns the errors as JSON []byte.
+func JValidateCtx(ctx context.Context, data interface{}, opts ...Option) ([]byte, error) {
+	return New(opts...).JValidateCtx(ctx, data)
+}
+
+// ErrorsCtx validates the data the same way as the ValidateCtx function and returns the errors as ValidationErrors.
+func ErrorsCtx(ctx context.Context, data interface{}, opts ...Option) (ValidationErrors, error) {
+	return New(opts...).ErrorsCtx(ctx, data)
+}
+
+// customErrorsOptions returns the options of the optional parameter CustomErrors.
+func customErrorsOptions(customErrors []map[string]string) []Option {
+	if len(customErrors) == 0 {
+		return nil
 	}
-	return parseFields(t, v, ce)
+	return []Option{CustomErrors(customErrors[0])}
 }
 
-// parseFields parses all the struct fields annotations. It get the fields and creates the
-// fieldProperties object of each of them.
+// errStop is returned by the parser to stop the validation after the first error.
+var errStop = errors.New("stop the validation")
+
+// parser describes the state of a single validation. It contains the options of
+// the validation and collects the errors of all fields.
+type parser struct {
+	// o contains the options of the validation.
+	o *options
+
+	// errs contains the errors of all fields.
+	errs ValidationErrors
+
+	// root is the validated struct.
+	root reflect.Value
+
+	// ctx is the context of the validation.
+	ctx context.Context
+
+	// messages contains the messages of the errors by rule name, in the locale of the validation.
+	messages map[string]string
+
+	// workers contains a token for each goroutine of the validation, except the caller's goroutine. It is nil
+	// if the validation is sequential.
+	workers chan struct{}
+
+	// field is the fieldProperties object of the field which is validated. It is reused for all
+	// the fields of the validation to avoid an allocation for each field.
+	field field.Field
+}
+
+// parseFields parses all the struct fields annotations. It get the compiled plan of the struct and
+// creates the fieldProperties object of each field.
+//
+// The nested structs are parsed recursively and their errors are collected under the dotted path of the
+// field e.g. "Address.PostCode". The fields of the embedded structs are promoted to the parent struct, so
+// their errors are collected under their own name, the same way encoding/json handles them.
 //
-// Finally it collects all the errors from validator and return them as map[FieldName]errorStringMessage.
-// If something go wrong it returns nil and an error message.
-func parseFields(t reflect.Type, v reflect.Value, ce map[string]string) (map[string][]string, error) {
-	var errs = make(map[string][]string)
-	for i := 0; i < t.NumField(); i++ {
-		tag := t.Field(i).Tag.Get("validate")
-		if tag == "" {
-			continue
+// After the fields, the ValidateStruct or the Validate function of the struct is called if it implements
+// StructValidator or Validatable.
+//
+// Finally it collects all the errors from validator and add them to p.errs.
+// If something go wrong it returns an error message.
+func (p *parser) parseFields(v reflect.Value, path string) error {
+	pl, err := planOf(v.Type(), p.o)
+	if err != nil {
+		return err
+	}
+	err = p.parseAll(len(pl.fields), func(p *parser, i int) error {
+		f := pl.fields[i]
+		fv, ok := fieldByIndex(v, f.index)
+		if !ok {
+			return nil
 		}
-		fp := &field.Field{
-			Kind:        t.Field(i).Type.String(),
-			Value:       v.Field(i).Interface(),
-			FieldName:   t.Field(i).Name,
-			CustomError: ce[t.Field(i).Name],
+		return p.parseValue(fv, path+f.name, f.node, v)
+	})
+	if err != nil {
+		return err
+	}
+	if pl.hooks {
+		structErrs := structHooks(p.ctx, v, path)
+		if p.o.stopOnFirstError && len(structErrs) > 0 {
+			p.errs = append(p.errs, structErrs[0])
+			return errStop
 		}
-		if valErrs, err := fp.CallValidator(strings.Split(tag, ",")); len(valErrs) > 0 || err != nil {
-			if err != nil {
-				return nil, err
+		p.errs = append(p.errs, structErrs...)
+	}
+	return nil
+}
+
+// parseValue validates the value of the field name according to the compiled validations of the node.
+// The parent is the struct which contains the field.
+//
+// The pointers and interfaces are dereferenced and a nil value is considered as a not provided field. The structs,
+// except the time.Time values and the numbers which are validated as a single value, are validated by the rules of
+// the field, e.g. required or a custom rule, and then they are parsed by parseFields. The validations after the
+// dive rule are applied to each element of the slices, arrays and maps, and the validations between the keys and
+// endkeys rules to each map key.
+// The errors of the elements are collected under the index or the key of the element
+// e.g. "Tags[3]" or "Quotas[eu]". If the context of the validation is done, it returns the error of the context.
+func (p *parser) parseValue(fv reflect.Value, name string, n *node, parent reflect.Value) error {
+	if err := p.ctx.Err(); err != nil {
+		return err
+	}
+	isNil := false
+	for !isNil && (fv.Kind() == reflect.Ptr || fv.Kind() == reflect.Interface) {
+		if fv.IsNil() {
+			isNil = true
+			fv = n.zero
+		} else if fv = fv.Elem(); n.zero.Kind() == reflect.Interface && fv.Kind() != reflect.Interface {
+			// Compile the validations of the interface value for its dynamic type.
+			var err error
+			if n, err = n.dynamic(fv.Type()); err != nil {
+				return errors.New("Invalid rules of the field " + name + ": " + err.Error())
 			}
-			errs[fp.FieldName] = valErrs
 		}
+	}
+	if n.rules != nil {
+		if !fv.CanInterface() {
+			return errors.New("Cannot validate the field " + name + ", the rules of an unexported embedded struct can not be applied")
+		}
+		p.field = field.Field{
+			Kind:        fv.Type().String(),
+			Value:       valueOf(fv),
+			FieldName:   name,
+			CustomError: p.o.customErrors[name],
+			Nil:         isNil,
+			Parent:      parent,
+			Root:        p.root,
+			Context:     p.ctx,
+			Registry:    p.o.registry,
+			Messages:    p.messages,
+		}
+		valErrs, err := n.rules.Validate(&p.field)
+		if err != nil {
+			return err
+		}
+		if p.o.stopOnFirstError && len(valErrs) > 0 {
+			p.errs = append(p.errs, valErrs[0])
+			return errStop
+		}
+		p.errs = append(p.errs, valErrs...)
+	}
+	if fv.Kind() == reflect.Struct && !isNil && !field.IsValueType(fv.Type()) {
+		return p.parseFields(fv, name+".")
+	}
+	if isNil || n.elem == nil {
+		return nil
+	}
+	switch fv.Kind() {
+	case reflect.Slice, reflect.Array:
+		return p.parseAll(fv.Len(), func(p *parser, i int) error {
+			return p.parseValue(fv.Index(i), name+"["+strconv.Itoa(i)+"]", n.elem, parent)
+		})
+	case reflect.Map:
+		keys := sortedKeys(fv)
+		return p.parseAll(len(keys), func(p *parser, i int) error {
+			key := name + "[" + fmt.Sprint(keys[i].Interface()) + "]"
+			if n.key != nil {
+				if err := p.parseValue(keys[i], key, n.key, parent); err != nil {
+					return err
+				}
+			}
+			return p.parseValue(fv.MapIndex(keys[i]), key, n.elem, parent)
+		})
+	}
+	return nil
+}
 
+// bigTypes contains the types of the math/big numbers.
+var bigTypes = map[reflect.Type]bool{
+	reflect.TypeOf(big.Int{}):   true,
+	reflect.TypeOf(big.Float{}): true,
+	reflect.TypeOf(big.Rat{}):   true,
+}
+
+// valueOf returns the value of the field v. The math/big numbers are returned as pointers, because they can not
+// be copied and their String and MarshalJSON functions have pointer receivers. If a math/big number is not
+// addressable, e.g. a map value, a pointer to a copy of it is returned.
+func valueOf(v reflect.Value) interface{} {
+	if !bigTypes[v.Type()] {
+		return v.Interface()
+	}
+	if v.CanAddr() {
+		return v.Addr().Interface()
+	}
+	p := reflect.New(v.Type())
+	p.Elem().Set(v)
+	return p.Interface()
+}
+
+// fieldByIndex returns the nested field of v by its index sequence. It returns false if the field is
+// promoted from a nil embedded struct pointer.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
 	}
-	return errs, nil
+	return v, true
 }
@@ -3,15 +3,17 @@ package valy_test
 import (
 	"encoding/json"
 	"github.com/cpapidas/valy"
+	"math/big"
 	"reflect"
 	"testing"
+	"time"
 )
 
 type demoUser struct {
 	Username       string  `validate:"required=true,min=10,max=23"`
 	Password       string  `validate:"required=true,Err=password is required"`
 	Email          string  `validate:"required=false"`
-	Age            int     `validate:"required=true,min=10,max=23"`
+	Age            int     `validate:"nonzero,min=10,max=23"`
 	Country        string  `validate:""`
 	FavoriteNumber uint8   `validate:"max=9"`
 	PostCode1      uint16  `validate:"required=true"`
@@ -68,7 +70,7 @@ func TestValidate_shouldReturnErrorForInvalidPassword(t *testing.T) {
 	if len(errs["Password"]) != 1 {
 		t.Error("should contains password property error for invalid password")
 	}
-	if errs["Password"][0] != "the field Password should not be empty" {
+	if errs["Password"][0] != "password is required" {
 		t.Error("should contains an error in Password property for invalid password")
 	}
 }
@@ -272,3 +274,480 @@ func TestValidate_json(t *testing.T) {
 		}
 	}(&djvO)
 }
+
+type demoAddress struct {
+	Street   string `validate:"required=true"`
+	PostCode string `validate:"min=5,max=5"`
+}
+
+type demoBase struct {
+	ID int `validate:"nonzero"`
+}
+
+type demoAudit struct {
+	CreatedBy string `validate:"required=true"`
+}
+
+type demoCustomer struct {
+	demoBase
+	demoAudit
+	Name    string `validate:"required=true"`
+	Address demoAddress
+	Billing struct {
+		Address demoAddress
+	}
+	Ignored demoAddress `validate:"-"`
+}
+
+func TestValidate_shouldValidateNestedStructs(t *testing.T) {
+	c := demoCustomer{demoBase: demoBase{ID: 1}, demoAudit: demoAudit{CreatedBy: "admin"}, Name: "cpapidas"}
+	c.Address.Street = "Main"
+	c.Address.PostCode = "123"
+	errs, err := valy.Validate(c)
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	expected := map[string][]string{
+		"Address.PostCode":         {"the field Address.PostCode should contains at least 5 characters"},
+		"Billing.Address.Street":   {"the field Billing.Address.Street should not be empty"},
+		"Billing.Address.PostCode": {"the field Billing.Address.PostCode should contains at least 5 characters"},
+	}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("expected %v, but got: %v", expected, errs)
+	}
+}
+
+type demoShipping struct {
+	Billing  demoAddress `validate:"required"`
+	Shipping demoAddress `validate:"nonzero,nefield=Billing"`
+}
+
+func TestValidate_shouldApplyTheRulesOfStructFields(t *testing.T) {
+	a := demoAddress{Street: "Main", PostCode: "12345"}
+	errs, err := valy.Validate(demoShipping{Shipping: a})
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	expected := map[string][]string{
+		"Billing":          {"the field Billing should not be empty"},
+		"Billing.Street":   {"the field Billing.Street should not be empty"},
+		"Billing.PostCode": {"the field Billing.PostCode should contains at least 5 characters"},
+	}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("expected %v, but got: %v", expected, errs)
+	}
+	errs, err = valy.Validate(demoShipping{Billing: a, Shipping: a})
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	expected = map[string][]string{
+		"Shipping": {"the field Shipping should not be equal to the field Billing"},
+	}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("expected %v, but got: %v", expected, errs)
+	}
+}
+
+func TestValidate_shouldPromoteEmbeddedStructFields(t *testing.T) {
+	c := demoCustomer{Name: "cpapidas"}
+	c.Address = demoAddress{Street: "Main", PostCode: "12345"}
+	c.Billing.Address = c.Address
+	errs, err := valy.Validate(c)
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	expected := map[string][]string{
+		"ID":        {"the field ID should not be zero"},
+		"CreatedBy": {"the field CreatedBy should not be empty"},
+	}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("expected %v, but got: %v", expected, errs)
+	}
+}
+
+type demoShadow struct {
+	demoBase
+	ID string `validate:"max=3"`
+}
+
+func TestValidate_shouldHidePromotedFieldsByShallowerFields(t *testing.T) {
+	errs, err := valy.Validate(demoShadow{ID: "1234"})
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	expected := map[string][]string{"ID": {"the field ID should contains max 3 characters"}}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("expected %v, but got: %v", expected, errs)
+	}
+}
+
+func TestValidate_shouldApplyCustomErrorsToNestedFields(t *testing.T) {
+	c := demoCustomer{demoBase: demoBase{ID: 1}, demoAudit: demoAudit{CreatedBy: "admin"}, Name: "cpapidas"}
+	c.Address = demoAddress{Street: "Main", PostCode: "12345"}
+	c.Billing.Address = demoAddress{PostCode: "12345"}
+	errs, err := valy.Validate(c, map[string]string{"Billing.Address.Street": "street is required"})
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	expected := map[string][]string{"Billing.Address.Street": {"street is required"}}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("expected %v, but got: %v", expected, errs)
+	}
+}
+
+type demoPatch struct {
+	Username *string      `validate:"required=true,min=5"`
+	Nickname *string      `validate:"min=5"`
+	Age      *int         `validate:"min=18"`
+	Score    *int         `validate:"min=10,checknil=true"`
+	Address  *demoAddress `validate:"required=true"`
+	Billing  *demoAddress
+	*demoAudit
+}
+
+func TestValidate_shouldAcceptPointerToStruct(t *testing.T) {
+	errs, err := valy.Validate(&demoJsonValidation{Demo: "123"})
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	if len(errs["Demo"]) != 1 {
+		t.Errorf("should return an error for the Demo field, but got: %v", errs)
+	}
+}
+
+func TestValidate_shouldReturnErrorForInvalidData(t *testing.T) {
+	var d *demoJsonValidation
+	if _, err := valy.Validate(d); err == nil {
+		t.Error("expected an error for the nil pointer but got nil")
+	}
+	if _, err := valy.Validate(nil); err == nil {
+		t.Error("expected an error for the nil value but got nil")
+	}
+	if _, err := valy.Validate("a string"); err == nil {
+		t.Error("expected an error for the non struct value but got nil")
+	}
+}
+
+func TestValidate_shouldTreatNilPointersAsNotProvided(t *testing.T) {
+	errs, err := valy.Validate(demoPatch{})
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	expected := map[string][]string{
+		"Username": {"the field Username should not be empty"},
+		"Score":    {"the field Score should be grater than 10"},
+		"Address":  {"the field Address should not be empty"},
+	}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("expected %v, but got: %v", expected, errs)
+	}
+}
+
+func TestValidate_shouldDereferencePointerFields(t *testing.T) {
+	username, nickname, age, score := "cpapidas", "cp", 16, 10
+	d := demoPatch{
+		Username:  &username,
+		Nickname:  &nickname,
+		Age:       &age,
+		Score:     &score,
+		Address:   &demoAddress{PostCode: "12345"},
+		demoAudit: &demoAudit{},
+	}
+	errs, err := valy.Validate(&d)
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	expected := map[string][]string{
+		"Nickname":       {"the field Nickname should contains at least 5 characters"},
+		"Age":            {"the field Age should be grater than 18"},
+		"Address.Street": {"the field Address.Street should not be empty"},
+		"CreatedBy":      {"the field CreatedBy should not be empty"},
+	}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("expected %v, but got: %v", expected, errs)
+	}
+}
+
+type demoSignup struct {
+	AcceptedTerms bool          `validate:"accepted"`
+	BirthDate     time.Time     `validate:"required,before=now-18y,after=1900-01-01"`
+	Timeout       time.Duration `validate:"min=1s,max=30s"`
+	ExpiresAt     *time.Time    `validate:"after=now"`
+}
+
+func TestValidate_shouldValidateBoolsTimesAndDurations(t *testing.T) {
+	expiresAt := time.Now().Add(-time.Minute)
+	d := demoSignup{
+		BirthDate: time.Now().AddDate(-10, 0, 0),
+		Timeout:   time.Minute,
+		ExpiresAt: &expiresAt,
+	}
+	errs, err := valy.Validate(d)
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	expected := map[string][]string{
+		"AcceptedTerms": {"the field AcceptedTerms should be accepted"},
+		"BirthDate":     {"the field BirthDate should be before now-18y"},
+		"Timeout":       {"the field Timeout should be at most 30s"},
+		"ExpiresAt":     {"the field ExpiresAt should be after now"},
+	}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("expected %v, but got: %v", expected, errs)
+	}
+	errs, err = valy.Validate(demoSignup{AcceptedTerms: true, BirthDate: time.Date(1990, 5, 1, 0, 0, 0, 0, time.UTC), Timeout: time.Second})
+	if err != nil || len(errs) != 0 {
+		t.Errorf("expected no errors but got: %v, %v", errs, err)
+	}
+}
+
+type demoEmailAddress string
+
+type demoCents int64
+
+type demoTags []demoEmailAddress
+
+type demoInvoice struct {
+	Email    demoEmailAddress `validate:"required,email"`
+	Amount   demoCents        `validate:"min=100,max=10000"`
+	Discount *demoCents       `validate:"max=50"`
+	CC       demoTags         `validate:"max=2,dive,email"`
+	Paid     demoFlag         `validate:"accepted"`
+	Rate     demoPercent      `validate:"max=1"`
+}
+
+type demoFlag bool
+
+type demoPercent float32
+
+func TestValidate_shouldValidateNamedTypesByTheirUnderlyingKind(t *testing.T) {
+	discount := demoCents(75)
+	d := demoInvoice{
+		Email:    "cpapidas",
+		Amount:   50,
+		Discount: &discount,
+		CC:       demoTags{"info@valy.dev", "valy.dev"},
+		Rate:     1.5,
+	}
+	errs, err := valy.Validate(d)
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	expected := map[string][]string{
+		"Email":    {"the field Email should be a valid email address"},
+		"Amount":   {"the field Amount should be grater than 100"},
+		"Discount": {"the field Discount should be less than 50"},
+		"CC[1]":    {"the field CC[1] should be a valid email address"},
+		"Paid":     {"the field Paid should be accepted"},
+		"Rate":     {"the field Rate should be less than 1"},
+	}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("expected %v, but got: %v", expected, errs)
+	}
+}
+
+type demoMoney struct {
+	cents int64
+}
+
+func (m demoMoney) Rat() *big.Rat {
+	return big.NewRat(m.cents, 100)
+}
+
+type demoBilling struct {
+	Balance  *big.Int   `validate:"required,max=1000000000000000000000"`
+	Rate     big.Float  `validate:"min=0.5,max=1.5"`
+	Total    demoMoney  `validate:"min=0.01,max=99.99"`
+	Invoices []*big.Int `validate:"dive,min=1"`
+	Limit    uint64     `validate:"max=18446744073709551614"`
+	Fee      *demoMoney `validate:"required"`
+}
+
+func TestValidate_shouldValidateBigNumbersAndDecimals(t *testing.T) {
+	balance, _ := new(big.Int).SetString("1000000000000000000001", 10)
+	d := demoBilling{
+		Balance:  balance,
+		Rate:     *big.NewFloat(0.25),
+		Total:    demoMoney{cents: 10000},
+		Invoices: []*big.Int{big.NewInt(3), big.NewInt(0)},
+		Limit:    18446744073709551615,
+	}
+	errs, err := valy.Validate(d)
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	expected := map[string][]string{
+		"Balance":     {"the field Balance should be less than 1000000000000000000000"},
+		"Rate":        {"the field Rate should be grater than 0.5"},
+		"Total":       {"the field Total should be less than 99.99"},
+		"Invoices[1]": {"the field Invoices[1] should be grater than 1"},
+		"Limit":       {"the field Limit should be less than 18446744073709551614"},
+		"Fee":         {"the field Fee should not be empty"},
+	}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("expected %v, but got: %v", expected, errs)
+	}
+}
+
+type demoReading struct {
+	Celsius  float64 `validate:"gte=-273.15,lt=100"`
+	Offset   int     `validate:"min=-10,max=-1"`
+	Humidity *int    `validate:"gt=0,lte=100"`
+}
+
+func TestValidate_shouldApplyNegativeAndExclusiveBounds(t *testing.T) {
+	humidity := 0
+	errs, err := valy.Validate(demoReading{Celsius: -300, Offset: 0, Humidity: &humidity})
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	expected := map[string][]string{
+		"Celsius":  {"the field Celsius should be greater than or equal to -273.15"},
+		"Offset":   {"the field Offset should be less than -1"},
+		"Humidity": {"the field Humidity should be greater than 0"},
+	}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("expected %v, but got: %v", expected, errs)
+	}
+	errs, err = valy.Validate(demoReading{Celsius: -273.15, Offset: -10})
+	if err != nil || len(errs) != 0 {
+		t.Errorf("expected no errors but got: %v, %v", errs, err)
+	}
+}
+
+type demoPayment struct {
+	Amount   float64 `validate:"finite,positive,decimals=2,digits=8"`
+	Quantity int     `validate:"step=6"`
+	Priority int     `validate:"oneof=1 2 3"`
+	Balance  int64   `validate:"nonzero"`
+}
+
+func TestValidate_shouldApplyTheNumericRules(t *testing.T) {
+	errs, err := valy.Validate(demoPayment{Amount: 10.005, Quantity: 8, Priority: 4})
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	expected := map[string][]string{
+		"Amount":   {"the field Amount should contains max 2 decimal places"},
+		"Quantity": {"the field Quantity should be a multiple of 6"},
+		"Priority": {"the field Priority should be one of 1, 2, 3"},
+		"Balance":  {"the field Balance should not be zero"},
+	}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("expected %v, but got: %v", expected, errs)
+	}
+	errs, err = valy.Validate(demoPayment{Amount: 10.05, Quantity: 12, Priority: 3, Balance: -1})
+	if err != nil || len(errs) != 0 {
+		t.Errorf("expected no errors but got: %v, %v", errs, err)
+	}
+}
+
+type demoOrderLine struct {
+	Quantity *int          `json:"quantity" validate:"required,min=0"`
+	Balance  int64         `json:"balance" validate:"nonzero"`
+	Discount float64       `json:"discount" validate:"omitempty,min=5"`
+	Coupon   string        `json:"coupon" validate:"omitempty,min=8"`
+	Note     *string       `json:"note" validate:"omitempty,required,min=3"`
+	Tags     []string      `json:"tags" validate:"omitempty,min=2"`
+	Paid     bool          `json:"paid" validate:"required"`
+	Delay    time.Duration `json:"delay" validate:"required,nonzero"`
+}
+
+func TestValidate_shouldSeparatePresenceFromZeroValues(t *testing.T) {
+	var d demoOrderLine
+	if err := json.Unmarshal([]byte(`{"balance": 0, "discount": 0, "coupon": ""}`), &d); err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	errs, err := valy.ValidateWith(d, valy.KeyTag("json"))
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	expected := map[string][]string{
+		"quantity": {"the field quantity should not be empty"},
+		"balance":  {"the field balance should not be zero"},
+		"note":     {"the field note should not be empty"},
+		"delay":    {"the field delay should not be zero"},
+	}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("expected %v, but got: %v", expected, errs)
+	}
+	d = demoOrderLine{}
+	if err := json.Unmarshal([]byte(`{"quantity": 0, "balance": -3, "discount": 2, "coupon": "FREE",
+		"note": "ok", "tags": ["a"], "delay": 1}`), &d); err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	errs, err = valy.ValidateWith(d, valy.KeyTag("json"))
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	expected = map[string][]string{
+		"discount": {"the field discount should be grater than 5"},
+		"coupon":   {"the field coupon should contains at least 8 characters"},
+		"note":     {"the field note should contains at least 3 characters"},
+		"tags":     {"the field tags should contains at least 2 items"},
+	}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("expected %v, but got: %v", expected, errs)
+	}
+}
+
+type demoCollections struct {
+	Tags      []string          `validate:"required=true,max=3,unique=true,dive,min=3"`
+	Quotas    map[string]int    `validate:"dive,keys,min=2,endkeys,max=10"`
+	Codes     [2]int            `validate:"dive,min=1"`
+	Matrix    [][]string        `validate:"dive,min=1,dive,max=2"`
+	Addresses []*demoAddress    `validate:"max=2"`
+	Nicknames []*string         `validate:"dive,required=true"`
+	Labels    map[string]string `validate:"dive,max=2"`
+}
+
+func TestValidate_shouldValidateCollections(t *testing.T) {
+	d := demoCollections{
+		Tags:      []string{"golang", "go", "golang", "valy"},
+		Quotas:    map[string]int{"eu": 11, "u": 5, "us": 1},
+		Matrix:    [][]string{{"a"}, {}, {"abc"}},
+		Addresses: []*demoAddress{{Street: "Main", PostCode: "12345"}, {PostCode: "12345"}, nil},
+		Nicknames: []*string{nil},
+	}
+	errs, err := valy.Validate(d)
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	expected := map[string][]string{
+		"Tags":                {"the field Tags should contains max 3 items", "the field Tags should contains unique items"},
+		"Tags[1]":             {"the field Tags[1] should contains at least 3 characters"},
+		"Quotas[eu]":          {"the field Quotas[eu] should be less than 10"},
+		"Quotas[u]":           {"the field Quotas[u] should contains at least 2 characters"},
+		"Codes[0]":            {"the field Codes[0] should be grater than 1"},
+		"Codes[1]":            {"the field Codes[1] should be grater than 1"},
+		"Matrix[1]":           {"the field Matrix[1] should contains at least 1 items"},
+		"Matrix[2][0]":        {"the field Matrix[2][0] should contains max 2 characters"},
+		"Addresses":           {"the field Addresses should contains max 2 items"},
+		"Addresses[1].Street": {"the field Addresses[1].Street should not be empty"},
+		"Nicknames[0]":        {"the field Nicknames[0] should not be empty"},
+	}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("expected %v, but got: %v", expected, errs)
+	}
+}
+
+func TestValidate_shouldReturnRequiredErrorForEmptyCollection(t *testing.T) {
+	errs, err := valy.Validate(demoCollections{Codes: [2]int{1, 2}})
+	if err != nil {
+		t.Fatalf("expected nill err but got: %v", err)
+	}
+	expected := map[string][]string{"Tags": {"the field Tags should not be empty"}}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("expected %v, but got: %v", expected, errs)
+	}
+}
+
+type demoInvalidKeys struct {
+	Quotas map[string]int `validate:"dive,keys,min=2"`
+}
+
+func TestValidate_shouldReturnErrorForUnclosedKeys(t *testing.T) {
+	if _, err := valy.Validate(demoInvalidKeys{}); err == nil {
+		t.Error("error should not be nil")
+	}
+}